@@ -2,18 +2,47 @@ package engi
 
 import (
 	"context"
+	"fmt"
+	"iter"
 	"net/http"
+	"strconv"
 
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+	"github.com/kliuchnikovv/engi/internal/types"
 )
 
 type (
 	Request  request.Requester
 	Response response.Responser
 	Route    func(ctx context.Context, request Request, response Response) error
+
+	// UUID - value of 'parameter.UUID' parameter.
+	UUID = request.UUID
+	// Decimal - value of 'parameter.Decimal' parameter.
+	Decimal = request.Decimal
+	// File - file uploaded with 'form.File' parameter.
+	File = request.File
+
+	// SSERoute - handler of event stream, see 'HandleSSE'.
+	SSERoute func(ctx context.Context, request Request, events SSEWriter) error
+	// SSEWriter - sends server-sent events to client.
+	SSEWriter = response.SSEWriter
+	// Event - server-sent event.
+	Event = response.Event
+
+	// ValidationError - failure of single validation rule, can be returned by 'validate.Request'.
+	ValidationError = types.ValidationError
+	// ValidationErrors - several validation failures responded together.
+	ValidationErrors = types.ValidationErrors
 )
 
+// ErrBodyTooLarge - request body exceeds limit, e.g. returned by reader of 'parameter.StreamBody' body.
+var ErrBodyTooLarge = request.ErrBodyTooLarge
+
 func Handle(route Route, middlewares ...Middleware) RouteByPath {
 	return func(srv *Service, method, path string) error {
 		return srv.addRoute(
@@ -27,6 +56,74 @@ func Handle(route Route, middlewares ...Middleware) RouteByPath {
 	}
 }
 
+// HandleSSE - registers handler of server-sent events stream, e.g. for 'GET' route.
+// Middlewares (e.g. auth or CORS) are run before stream is opened, so request can still be rejected.
+// Stream isn't cut by server's write timeout and is closed when handler returns or client goes away (see 'ctx').
+// Heartbeat comments are sent every 'response.DefaultHeartbeat' (see 'response.Heartbeat').
+// Identifier of last event client got is taken from 'Last-Event-ID' header or 'lastEventId' query parameter.
+func HandleSSE(route SSERoute, middlewares ...Middleware) RouteByPath {
+	return func(srv *Service, method, path string) error {
+		return srv.addRoute(
+			method,
+			path,
+			func(ctx context.Context, request *request.Request, response *response.Response) error {
+				var lastEventID = request.GetRequest().Header.Get("Last-Event-ID")
+				if lastEventID == "" {
+					lastEventID = request.GetRequest().URL.Query().Get("lastEventId")
+				}
+
+				return response.EventStream(lastEventID, func(events SSEWriter) error {
+					return route(ctx, request, events)
+				})
+			},
+			append(middlewares[:len(middlewares):len(middlewares)], eventStream{})...,
+		)
+	}
+}
+
+// eventStream - describes response of event stream.
+type eventStream struct{}
+
+func (eventStream) Handle(context.Context, *request.Request, *response.Response) error {
+	return nil
+}
+
+func (eventStream) Docs(route *routes.Route) {
+	route.Operation.Responses[strconv.Itoa(http.StatusOK)] = &docs.Response{
+		Description: "Stream of server-sent events",
+		Content: map[string]*docs.MediaType{
+			"text/event-stream": {Schema: &docs.Schema{Type: "string"}},
+		},
+	}
+}
+
+func (eventStream) Priority() int {
+	return 0
+}
+
+// Param - returns typed value of parameter requested by middleware (e.g. 'parameter.Custom').
+// Returns false if parameter wasn't requested, wasn't sent or has another type.
+func Param[T any](request Request, key string, place placing.Placing) (T, bool) {
+	result, ok := request.Value(key, place).(T)
+
+	return result, ok
+}
+
+// Items - returns iterator over items of body requested by 'parameter.NDJSON[T]'.
+// Items are decoded while they're iterated, see 'parameter.NDJSON' for details.
+func Items[T any](ctx context.Context, req Request) iter.Seq2[T, error] {
+	items, ok := req.Body().(*request.Items[T])
+	if !ok {
+		return func(yield func(T, error) bool) {
+			var zero T
+
+			yield(zero, fmt.Errorf("body of %T items wasn't requested", zero))
+		}
+	}
+
+	return items.All(ctx)
+}
+
 func NewMethod(method, path string) RouteMethodPair {
 	return RouteMethodPair{
 		method: method,
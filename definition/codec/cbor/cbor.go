@@ -0,0 +1,30 @@
+// Package cbor - CBOR (RFC 8949) codec, compact binary alternative to JSON.
+package cbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/response"
+)
+
+const ContentType = "application/cbor"
+
+// Codec - CBOR codec for 'engi.WithCodecs'.
+var Codec = codec.New(ContentType, Marshal, Unmarshal)
+
+// Marshal - encodes value, fields of structures are named by 'cbor' or 'json' tags.
+// Wrapped payload (e.g. of 'response.AsIs') is encoded without wrapper.
+func Marshal(value interface{}) ([]byte, error) {
+	return cbor.Marshal(codec.Unwrap(value))
+}
+
+// Unmarshal - decodes data into pointer, fields of structures are named by 'cbor' or 'json' tags.
+// Can be used with 'parameter.CustomBody'.
+func Unmarshal(data []byte, pointer interface{}) error {
+	return cbor.Unmarshal(data, pointer)
+}
+
+// Marshaler - returns CBOR marshaler for 'response.MarshalAs'.
+func Marshaler() response.Marshaler {
+	return response.AsCodec(Codec)
+}
@@ -0,0 +1,163 @@
+package codec
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+)
+
+type (
+	// Codec - encodes and decodes bodies of one media type.
+	Codec interface {
+		// ContentType - media type of codec without parameters, e.g. 'application/json'.
+		ContentType() string
+		// Marshal - encodes value.
+		Marshal(interface{}) ([]byte, error)
+		// Unmarshal - decodes data into pointer.
+		Unmarshal([]byte, interface{}) error
+	}
+
+	// Wrapper - object wrapping payload of response (e.g. 'response.AsIs').
+	// Formats without their own rules for such objects encode only payload (see 'Unwrap').
+	Wrapper interface {
+		Payload() interface{}
+	}
+
+	// Decoder - codec decoding only some types, e.g. text codec decodes only strings.
+	// Codecs not implementing it decode any type.
+	Decoder interface {
+		// Decodes - reports whether data can be decoded into pointer of type.
+		Decodes(reflect.Type) bool
+	}
+
+	codec struct {
+		contentType string
+		marshal     func(interface{}) ([]byte, error)
+		unmarshal   func([]byte, interface{}) error
+	}
+
+	restricted struct {
+		Codec
+
+		decodes func(reflect.Type) bool
+	}
+)
+
+var (
+	// JSON - 'application/json' codec using encoding/json.
+	JSON = New("application/json", json.Marshal, json.Unmarshal)
+	// XML - 'application/xml' codec using encoding/xml, encoded values are prefixed with XML header.
+	XML = New("application/xml", marshalXML, xml.Unmarshal)
+	// Text - 'text/plain' codec, decodes into '*string', '*[]byte' or 'encoding.TextUnmarshaler',
+	// encodes strings, 'encoding.TextMarshaler', 'fmt.Stringer' and errors, other values are formatted by fmt.
+	Text = Restrict(New("text/plain", marshalText, unmarshalText), decodesText)
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	stringPointerType   = reflect.TypeOf((*string)(nil))
+	bytesPointerType    = reflect.TypeOf((*[]byte)(nil))
+)
+
+// New - creates codec of media type from functions.
+func New(
+	contentType string,
+	marshal func(interface{}) ([]byte, error),
+	unmarshal func([]byte, interface{}) error,
+) Codec {
+	return &codec{
+		contentType: contentType,
+		marshal:     marshal,
+		unmarshal:   unmarshal,
+	}
+}
+
+func (c *codec) ContentType() string {
+	return c.contentType
+}
+
+func (c *codec) Marshal(value interface{}) ([]byte, error) {
+	return c.marshal(value)
+}
+
+func (c *codec) Unmarshal(data []byte, pointer interface{}) error {
+	return c.unmarshal(data, pointer)
+}
+
+// Restrict - makes codec decode only types 'decodes' reports, other types aren't documented as decoded by it.
+func Restrict(codec Codec, decodes func(reflect.Type) bool) Codec {
+	return &restricted{
+		Codec:   codec,
+		decodes: decodes,
+	}
+}
+
+func (c *restricted) Decodes(typ reflect.Type) bool {
+	return c.decodes(typ)
+}
+
+// Decodes - reports whether codec can decode data into pointer of type.
+func Decodes(codec Codec, typ reflect.Type) bool {
+	if decoder, ok := codec.(Decoder); ok {
+		return decoder.Decodes(typ)
+	}
+
+	return true
+}
+
+// Unwrap - returns payload of wrapper or value itself.
+func Unwrap(value interface{}) interface{} {
+	if wrapper, ok := value.(Wrapper); ok {
+		return wrapper.Payload()
+	}
+
+	return value
+}
+
+func marshalXML(value interface{}) ([]byte, error) {
+	bytes, err := xml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Should append header for proper visualization.
+	return append([]byte(xml.Header), bytes...), nil
+}
+
+func marshalText(value interface{}) ([]byte, error) {
+	switch typed := value.(type) {
+	case string:
+		return []byte(typed), nil
+	case []byte:
+		return typed, nil
+	case encoding.TextMarshaler:
+		return typed.MarshalText()
+	case fmt.Stringer:
+		return []byte(typed.String()), nil
+	case error:
+		return []byte(typed.Error()), nil
+	default:
+		return []byte(fmt.Sprint(value)), nil
+	}
+}
+
+func decodesText(typ reflect.Type) bool {
+	return typ == stringPointerType || typ == bytesPointerType || typ.Implements(textUnmarshalerType)
+}
+
+func unmarshalText(data []byte, pointer interface{}) error {
+	switch typed := pointer.(type) {
+	case *string:
+		*typed = string(data)
+	case *[]byte:
+		*typed = append((*typed)[:0], data...)
+	case encoding.TextUnmarshaler:
+		return typed.UnmarshalText(data)
+	default:
+		return fmt.Errorf("can't decode text into %T", pointer)
+	}
+
+	return nil
+}
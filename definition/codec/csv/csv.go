@@ -0,0 +1,422 @@
+// Package csv - CSV codec for slices of structures, e.g. exports of tables.
+package csv
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/response"
+)
+
+const ContentType = "text/csv"
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Codec - CSV codec for 'engi.WithCodecs'.
+var Codec = codec.Restrict(codec.New(ContentType, Marshal, Unmarshal), decodes)
+
+// Marshal - encodes slice of structures (or single structure) as CSV with header.
+// Columns are named by 'csv' or 'json' tags, strings and errors (e.g. error responses) are encoded as single cell.
+// Wrapped payload (e.g. of 'response.AsIs') is encoded without wrapper.
+func Marshal(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := NewEncoder(&buffer).Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// decodes - reports whether type is pointer to slice of structures.
+func decodes(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Pointer &&
+		typ.Elem().Kind() == reflect.Slice &&
+		indirectType(typ.Elem().Elem()).Kind() == reflect.Struct
+}
+
+// Unmarshal - decodes CSV with header into pointer to slice of structures.
+// Columns are matched with fields by 'csv' or 'json' tags, unknown columns are skipped.
+// Can be used with 'parameter.CustomBody'.
+func Unmarshal(data []byte, pointer interface{}) error {
+	var v = reflect.ValueOf(pointer)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("can't decode CSV into %T: pointer to slice expected", pointer)
+	}
+
+	var (
+		slice    = v.Elem()
+		elemType = slice.Type().Elem()
+		reader   = csv.NewReader(bytes.NewReader(data))
+	)
+
+	if indirectType(elemType).Kind() != reflect.Struct {
+		return fmt.Errorf("can't decode CSV into %T: slice of structures expected", pointer)
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		slice.SetLen(0)
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var (
+		fields  = fieldsOf(indirectType(elemType))
+		columns = make([]*field, len(header))
+		result  = reflect.MakeSlice(slice.Type(), 0, 0)
+	)
+
+	for i, name := range header {
+		for j := range fields {
+			if fields[j].name == strings.TrimSpace(name) {
+				columns[i] = &fields[j]
+			}
+		}
+	}
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var elem = reflect.New(indirectType(elemType)).Elem()
+
+		for i, cell := range record {
+			if i >= len(columns) || columns[i] == nil {
+				continue
+			}
+
+			if err := parse(fieldByIndex(elem, columns[i].index), cell); err != nil {
+				return fmt.Errorf("row %d, column '%s': %w", row, columns[i].name, err)
+			}
+		}
+
+		if elemType.Kind() == reflect.Pointer {
+			elem = elem.Addr()
+		}
+
+		result = reflect.Append(result, elem)
+	}
+
+	slice.Set(result)
+
+	return nil
+}
+
+// Marshaler - returns CSV marshaler for 'response.MarshalAs'.
+func Marshaler() response.Marshaler {
+	return response.AsCodec(Codec)
+}
+
+// Encoder - writes structures as CSV rows, header is written before first row.
+// Rows are flushed after every 'Encode' call, so long tables can be streamed in parts.
+type Encoder struct {
+	writer *csv.Writer
+	fields []field
+}
+
+// NewEncoder - creates encoder writing to 'w'.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		writer: csv.NewWriter(w),
+	}
+}
+
+// Encode - writes rows of slice of structures or single structure.
+// Strings and errors are written as row of single cell.
+func (encoder *Encoder) Encode(value interface{}) error {
+	value = codec.Unwrap(value)
+
+	switch typed := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return encoder.write([]string{typed})
+	case error:
+		// Validation errors are written as table.
+		if v := reflect.ValueOf(typed); v.Kind() != reflect.Slice {
+			return encoder.write([]string{typed.Error()})
+		}
+	}
+
+	var v = reflect.ValueOf(value)
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct:
+		return encoder.row(v)
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && indirectType(v.Type().Elem()).Kind() == reflect.Struct:
+		for i := 0; i < v.Len(); i++ {
+			var elem = v.Index(i)
+
+			for elem.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+
+			if !elem.IsValid() {
+				continue
+			}
+
+			if err := encoder.row(elem); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("can't encode %T as CSV: structure or slice of structures expected", value)
+	}
+}
+
+func (encoder *Encoder) row(v reflect.Value) error {
+	if encoder.fields == nil {
+		encoder.fields = fieldsOf(v.Type())
+
+		var header = make([]string, len(encoder.fields))
+
+		for i, field := range encoder.fields {
+			header[i] = field.name
+		}
+
+		if err := encoder.writer.Write(header); err != nil {
+			return err
+		}
+	}
+
+	var record = make([]string, len(encoder.fields))
+
+	for i, field := range encoder.fields {
+		cell, err := format(fieldByIndex(v, field.index))
+		if err != nil {
+			return fmt.Errorf("column '%s': %w", field.name, err)
+		}
+
+		record[i] = cell
+	}
+
+	return encoder.write(record)
+}
+
+func (encoder *Encoder) write(record []string) error {
+	if err := encoder.writer.Write(record); err != nil {
+		return err
+	}
+
+	encoder.writer.Flush()
+
+	return encoder.writer.Error()
+}
+
+// field - column of table.
+type field struct {
+	name  string
+	index []int
+}
+
+// fieldsOf - returns exported fields of structure, fields of embedded structures are included.
+func fieldsOf(typ reflect.Type) []field {
+	var result []field
+
+	for i := 0; i < typ.NumField(); i++ {
+		var structField = typ.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		var name = columnName(structField)
+		if name == "-" {
+			continue
+		}
+
+		if name == "" && structField.Anonymous && indirectType(structField.Type).Kind() == reflect.Struct {
+			for _, embedded := range fieldsOf(indirectType(structField.Type)) {
+				embedded.index = append([]int{i}, embedded.index...)
+				result = append(result, embedded)
+			}
+
+			continue
+		}
+
+		if name == "" {
+			name = structField.Name
+		}
+
+		result = append(result, field{name: name, index: []int{i}})
+	}
+
+	return result
+}
+
+func columnName(field reflect.StructField) string {
+	for _, key := range []string{"csv", "json"} {
+		if tag, ok := field.Tag.Lookup(key); ok {
+			name, _, _ := strings.Cut(tag, ",")
+
+			return name
+		}
+	}
+
+	return ""
+}
+
+// fieldByIndex - returns field by index, nil embedded pointers are allocated if value is settable.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 {
+			for v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					if !v.CanSet() {
+						return reflect.Value{}
+					}
+
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+
+				v = v.Elem()
+			}
+		}
+
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+// format - returns textual representation of cell.
+func format(v reflect.Value) (string, error) {
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return "", nil
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+
+		return string(text), err
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		text, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	default:
+		return fmt.Sprint(v.Interface()), nil
+	}
+}
+
+// parse - sets cell's value into field.
+func parse(v reflect.Value, cell string) error {
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if cell == "" {
+			return nil
+		}
+
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	if v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(cell))
+	}
+
+	if cell == "" && v.Kind() != reflect.String {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+	case reflect.Bool:
+		result, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(result)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(result)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(result)
+	case reflect.Float32, reflect.Float64:
+		result, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(result)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
@@ -0,0 +1,183 @@
+package codec_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/codec/cbor"
+	"github.com/kliuchnikovv/engi/definition/codec/csv"
+	"github.com/kliuchnikovv/engi/definition/codec/msgpack"
+	"github.com/kliuchnikovv/engi/definition/codec/protobuf"
+	"github.com/kliuchnikovv/engi/definition/codec/yaml"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type row struct {
+	ID      int64      `json:"id"`
+	Name    string     `json:"name"`
+	Score   float64    `json:"score"     csv:"points"`
+	Created time.Time  `json:"created"`
+	Deleted *time.Time `json:"deleted"`
+	secret  string
+}
+
+func TestFormatsRoundTrip(t *testing.T) {
+	var rows = []row{
+		{ID: 1, Name: "a, \"quoted\"", Score: 1.5, Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{ID: 2, Name: "b", Created: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range []codec.Codec{msgpack.Codec, cbor.Codec, yaml.Codec, csv.Codec} {
+		data, err := c.Marshal(rows)
+		assert.NoError(t, err, c.ContentType())
+
+		var result []row
+		assert.NoError(t, c.Unmarshal(data, &result), c.ContentType())
+
+		// Binary formats decode time in local zone.
+		for i := range result {
+			result[i].Created = result[i].Created.UTC()
+		}
+
+		assert.Equal(t, rows, result, c.ContentType())
+	}
+
+	data, err := protobuf.Marshal(wrapperspb.Int64(42))
+	assert.NoError(t, err)
+
+	var message wrapperspb.Int64Value
+	assert.NoError(t, protobuf.Unmarshal(data, &message))
+	assert.Equal(t, int64(42), message.GetValue())
+
+	_, err = protobuf.Marshal(rows)
+	assert.EqualError(t, err, "can't encode []codec_test.row: not a proto.Message")
+	assert.EqualError(t, protobuf.Unmarshal(data, new(int)), "can't decode into *int: not a proto.Message")
+}
+
+func TestCSV(t *testing.T) {
+	data, err := csv.Marshal([]*row{{ID: 1, Name: "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "id,name,points,created,deleted\n1,a,0,0001-01-01T00:00:00Z,\n", string(data))
+
+	var result []row
+	assert.NoError(t, csv.Unmarshal([]byte("points,unknown,id\n2.5,x,7\n"), &result))
+	assert.Equal(t, []row{{ID: 7, Score: 2.5}}, result)
+
+	assert.EqualError(t, csv.Unmarshal([]byte("id\nabc\n"), &result),
+		`row 1, column 'id': strconv.ParseInt: parsing "abc": invalid syntax`)
+	assert.EqualError(t, csv.Unmarshal([]byte("id\n"), new(row)),
+		"can't decode CSV into *codec_test.row: pointer to slice expected")
+
+	// Encoder flushes rows after every call, header is written once.
+	var (
+		buffer  bytes.Buffer
+		encoder = csv.NewEncoder(&buffer)
+	)
+
+	assert.NoError(t, encoder.Encode(row{ID: 1}))
+	assert.Equal(t, "id,name,points,created,deleted\n1,,0,0001-01-01T00:00:00Z,\n", buffer.String())
+	assert.NoError(t, encoder.Encode([]row{{ID: 2}}))
+	assert.Equal(t, "id,name,points,created,deleted\n1,,0,0001-01-01T00:00:00Z,\n2,,0,0001-01-01T00:00:00Z,\n", buffer.String())
+
+	data, err = csv.Marshal(errors.New("failed"))
+	assert.NoError(t, err)
+	assert.Equal(t, "failed\n", string(data))
+}
+
+type exportService struct {
+	rows []row
+}
+
+func (s *exportService) Prefix() string {
+	return "export"
+}
+
+func (s *exportService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET("csv"): engi.Handle(s.list,
+			response.MarshalAs(csv.Marshaler),
+		),
+		engi.PST("msgpack"): engi.Handle(s.echo,
+			response.MarshalAs(msgpack.Marshaler),
+			parameter.CustomBody(msgpack.Unmarshal, new([]row)),
+		),
+		engi.PST("proto"): engi.Handle(
+			func(_ context.Context, req engi.Request, resp engi.Response) error {
+				return resp.OK(req.Body())
+			},
+			response.MarshalAs(protobuf.Marshaler),
+			parameter.CustomBody(protobuf.Unmarshal, new(wrapperspb.StringValue)),
+		),
+	}
+}
+
+func (s *exportService) list(_ context.Context, _ engi.Request, resp engi.Response) error {
+	return resp.OK(s.rows)
+}
+
+func (s *exportService) echo(_ context.Context, req engi.Request, resp engi.Response) error {
+	return resp.OK(req.Body())
+}
+
+func TestFormatsInRoutes(t *testing.T) {
+	var (
+		rows   = []row{{ID: 1, Name: "a"}}
+		engine = engi.New("")
+	)
+
+	assert.NoError(t, engine.RegisterServices(&exportService{rows: rows}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/export/csv")
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "id,name,points,created,deleted\n1,a,0,0001-01-01T00:00:00Z,\n", string(body))
+
+	data, err := msgpack.Marshal(rows)
+	assert.NoError(t, err)
+
+	resp, err = http.Post(server.URL+"/export/msgpack", msgpack.ContentType, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	var result []row
+	assert.Equal(t, msgpack.ContentType, resp.Header.Get("Content-Type"))
+	assert.NoError(t, msgpack.Unmarshal(body, &result))
+	assert.Equal(t, rows, result)
+
+	data, err = proto.Marshal(wrapperspb.String("ping"))
+	assert.NoError(t, err)
+
+	resp, err = http.Post(server.URL+"/export/proto", protobuf.ContentType, bytes.NewReader(data))
+	assert.NoError(t, err)
+
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	var message wrapperspb.StringValue
+	assert.NoError(t, proto.Unmarshal(body, &message))
+	assert.Equal(t, "ping", message.GetValue())
+}
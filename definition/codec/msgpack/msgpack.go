@@ -0,0 +1,47 @@
+// Package msgpack - MessagePack codec, compact binary alternative to JSON.
+package msgpack
+
+import (
+	"bytes"
+
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const ContentType = "application/msgpack"
+
+// Codec - MessagePack codec for 'engi.WithCodecs'.
+var Codec = codec.New(ContentType, Marshal, Unmarshal)
+
+// Marshal - encodes value, fields of structures are named by 'json' tags.
+// Wrapped payload (e.g. of 'response.AsIs') is encoded without wrapper.
+func Marshal(value interface{}) ([]byte, error) {
+	var (
+		buffer  bytes.Buffer
+		encoder = msgpack.NewEncoder(&buffer)
+	)
+
+	encoder.SetCustomStructTag("json")
+
+	if err := encoder.Encode(codec.Unwrap(value)); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Unmarshal - decodes data into pointer, fields of structures are named by 'json' tags.
+// Can be used with 'parameter.CustomBody'.
+func Unmarshal(data []byte, pointer interface{}) error {
+	var decoder = msgpack.NewDecoder(bytes.NewReader(data))
+
+	decoder.SetCustomStructTag("json")
+
+	return decoder.Decode(pointer)
+}
+
+// Marshaler - returns MessagePack marshaler for 'response.MarshalAs'.
+func Marshaler() response.Marshaler {
+	return response.AsCodec(Codec)
+}
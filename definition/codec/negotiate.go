@@ -0,0 +1,99 @@
+package codec
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// mediaRange - media range of 'Accept' header, e.g. 'text/*;q=0.5'.
+type mediaRange struct {
+	typ, subtype string
+	quality      float64
+}
+
+// Negotiate - returns offered media type client prefers according to 'Accept' header.
+// Quality values and wildcards ('*/*', 'type/*') are honored, more specific range defines quality of type.
+// Ties are broken by order of offers, first offer is returned for empty header.
+// Returns false if client accepts none of offers.
+func Negotiate(accept string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+
+	var (
+		ranges      = parseAccept(accept)
+		best        string
+		bestQuality float64
+	)
+
+	for _, offer := range offers {
+		if quality := qualityOf(ranges, offer); quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best, bestQuality > 0
+}
+
+func parseAccept(accept string) []mediaRange {
+	var result []mediaRange
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok {
+			continue
+		}
+
+		var quality = 1.0
+
+		if value, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(value, 64); err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+
+		result = append(result, mediaRange{typ: typ, subtype: subtype, quality: quality})
+	}
+
+	return result
+}
+
+// qualityOf - returns quality of the most specific range matching media type.
+func qualityOf(ranges []mediaRange, mediaType string) float64 {
+	var (
+		typ, subtype, _ = strings.Cut(strings.ToLower(mediaType), "/")
+		quality         float64
+		specificity     = -1
+	)
+
+	for _, r := range ranges {
+		var current int
+
+		switch {
+		case r.typ == typ && r.subtype == subtype:
+			current = 2
+		case r.typ == typ && r.subtype == "*":
+			current = 1
+		case r.typ == "*" && r.subtype == "*":
+			current = 0
+		default:
+			continue
+		}
+
+		if current > specificity {
+			quality, specificity = r.quality, current
+		}
+	}
+
+	return quality
+}
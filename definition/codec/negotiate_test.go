@@ -0,0 +1,41 @@
+package codec_test
+
+import (
+	"testing"
+
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	var offers = []string{"application/json", "application/xml", "text/plain"}
+
+	tests := []struct {
+		accept string
+		want   string
+		wantOK bool
+	}{
+		{"", "application/json", true},
+		{"*/*", "application/json", true},
+		{"application/xml", "application/xml", true},
+		{"Application/XML", "application/xml", true},
+		{"text/*", "text/plain", true},
+		{"application/json;q=0.5, application/xml", "application/xml", true},
+		{"application/*;q=0.8, application/xml;q=0.1", "application/json", true},
+		{"text/plain;q=0.9, */*;q=0.1", "text/plain", true},
+		{"*/*, application/json;q=0", "application/xml", true},
+		{"image/png", "", false},
+		{"application/json;q=0", "", false},
+		{"application/json;q=abc, text/html", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := codec.Negotiate(tt.accept, offers)
+
+		assert.Equal(t, tt.wantOK, ok, tt.accept)
+		assert.Equal(t, tt.want, got, tt.accept)
+	}
+
+	_, ok := codec.Negotiate("*/*", nil)
+	assert.False(t, ok)
+}
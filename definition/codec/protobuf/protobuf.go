@@ -0,0 +1,52 @@
+// Package protobuf - Protocol Buffers codec for 'proto.Message' payloads.
+package protobuf
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const ContentType = "application/x-protobuf"
+
+// Codec - Protocol Buffers codec for 'engi.WithCodecs'.
+var Codec = codec.Restrict(codec.New(ContentType, Marshal, Unmarshal), func(typ reflect.Type) bool {
+	return typ.Implements(messageType)
+})
+
+var messageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+
+// Marshal - encodes 'proto.Message', strings and errors (e.g. error responses) are encoded as 'google.protobuf.StringValue'.
+// Wrapped payload (e.g. of 'response.AsIs') is encoded without wrapper.
+func Marshal(value interface{}) ([]byte, error) {
+	switch typed := codec.Unwrap(value).(type) {
+	case proto.Message:
+		return proto.Marshal(typed)
+	case string:
+		return proto.Marshal(wrapperspb.String(typed))
+	case error:
+		return proto.Marshal(wrapperspb.String(typed.Error()))
+	default:
+		return nil, fmt.Errorf("can't encode %T: not a proto.Message", typed)
+	}
+}
+
+// Unmarshal - decodes data into pointer, pointer should implement 'proto.Message'.
+// Can be used with 'parameter.CustomBody'.
+func Unmarshal(data []byte, pointer interface{}) error {
+	message, ok := pointer.(proto.Message)
+	if !ok {
+		return fmt.Errorf("can't decode into %T: not a proto.Message", pointer)
+	}
+
+	return proto.Unmarshal(data, message)
+}
+
+// Marshaler - returns Protocol Buffers marshaler for 'response.MarshalAs'.
+func Marshaler() response.Marshaler {
+	return response.AsCodec(Codec)
+}
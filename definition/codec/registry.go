@@ -0,0 +1,118 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strings"
+)
+
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// Registry - codecs by their media types.
+//
+// Codecs should be registered before serving requests.
+type Registry struct {
+	codecs map[string]Codec
+	order  []string
+}
+
+// NewRegistry - creates registry of codecs, first codec is used for requests without 'Content-Type'.
+func NewRegistry(codecs ...Codec) *Registry {
+	var registry = Registry{
+		codecs: make(map[string]Codec, len(codecs)),
+	}
+
+	registry.Register(codecs...)
+
+	return &registry
+}
+
+// Default - creates registry of JSON, XML and text codecs.
+func Default() *Registry {
+	return NewRegistry(JSON, XML, Text)
+}
+
+// Register - adds codecs to registry replacing codecs of the same media types.
+func (registry *Registry) Register(codecs ...Codec) {
+	for _, codec := range codecs {
+		var contentType = strings.ToLower(codec.ContentType())
+
+		if _, ok := registry.codecs[contentType]; !ok {
+			registry.order = append(registry.order, contentType)
+		}
+
+		registry.codecs[contentType] = codec
+	}
+}
+
+// Lookup - returns codec for value of 'Content-Type' header, parameters (e.g. 'charset') are ignored.
+// Structured syntax suffixes are supported: 'application/problem+json' is decoded by 'application/json' codec.
+// First registered codec is returned for empty value.
+func (registry *Registry) Lookup(contentType string) (Codec, error) {
+	if strings.TrimSpace(contentType) == "" {
+		if len(registry.order) == 0 {
+			return nil, fmt.Errorf("%w: no codecs registered", ErrUnsupportedMediaType)
+		}
+
+		return registry.codecs[registry.order[0]], nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: '%s': %s", ErrUnsupportedMediaType, contentType, err)
+	}
+
+	if codec, ok := registry.codecs[mediaType]; ok {
+		return codec, nil
+	}
+
+	if _, subtype, ok := strings.Cut(mediaType, "/"); ok {
+		if index := strings.LastIndex(subtype, "+"); index >= 0 {
+			if codec, ok := registry.codecs["application/"+subtype[index+1:]]; ok {
+				return codec, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: '%s', supported: %s",
+		ErrUnsupportedMediaType, mediaType, strings.Join(registry.order, ", "),
+	)
+}
+
+// Codecs - returns registered codecs in order of registration.
+func (registry *Registry) Codecs() []Codec {
+	var result = make([]Codec, len(registry.order))
+
+	for i, contentType := range registry.order {
+		result[i] = registry.codecs[contentType]
+	}
+
+	return result
+}
+
+// Get - returns codec registered for media type.
+func (registry *Registry) Get(contentType string) (Codec, bool) {
+	codec, ok := registry.codecs[strings.ToLower(contentType)]
+
+	return codec, ok
+}
+
+// DecodingTypes - returns media types of registered codecs which can decode into pointer of type.
+func (registry *Registry) DecodingTypes(typ reflect.Type) []string {
+	var result []string
+
+	for _, contentType := range registry.order {
+		if Decodes(registry.codecs[contentType], typ) {
+			result = append(result, contentType)
+		}
+	}
+
+	return result
+}
+
+// ContentTypes - returns media types of registered codecs in order of registration.
+func (registry *Registry) ContentTypes() []string {
+	return append([]string(nil), registry.order...)
+}
@@ -0,0 +1,108 @@
+package codec_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/codec/csv"
+	"github.com/kliuchnikovv/engi/definition/codec/protobuf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestLookup(t *testing.T) {
+	var (
+		yaml     = codec.New("application/yaml", nil, nil)
+		registry = codec.NewRegistry(codec.JSON, codec.XML, yaml)
+	)
+
+	tests := []struct {
+		contentType string
+		want        codec.Codec
+		wantErr     string
+	}{
+		{"", codec.JSON, ""},
+		{"application/json", codec.JSON, ""},
+		{"Application/JSON; charset=utf-8", codec.JSON, ""},
+		{"application/problem+json", codec.JSON, ""},
+		{"application/atom+xml", codec.XML, ""},
+		{"application/yaml", yaml, ""},
+		{"text/csv", nil, "unsupported media type: 'text/csv', supported: application/json, application/xml, application/yaml"},
+		{"application/vnd+csv", nil, "unsupported media type: 'application/vnd+csv', supported: application/json, application/xml, application/yaml"},
+		{"application/", nil, "unsupported media type: 'application/': mime: expected token after slash"},
+	}
+
+	for _, tt := range tests {
+		got, err := registry.Lookup(tt.contentType)
+
+		if tt.wantErr != "" {
+			assert.ErrorIs(t, err, codec.ErrUnsupportedMediaType, tt.contentType)
+			assert.EqualError(t, err, tt.wantErr, tt.contentType)
+
+			continue
+		}
+
+		assert.NoError(t, err, tt.contentType)
+		assert.Same(t, tt.want, got, tt.contentType)
+	}
+
+	_, err := codec.NewRegistry().Lookup("")
+	assert.ErrorIs(t, err, codec.ErrUnsupportedMediaType)
+}
+
+func TestRegister(t *testing.T) {
+	var (
+		registry = codec.Default()
+		custom   = codec.New("application/JSON", nil, nil)
+	)
+
+	registry.Register(custom)
+
+	got, err := registry.Lookup("application/json")
+	assert.NoError(t, err)
+	assert.Same(t, custom, got)
+	assert.Equal(t, []string{"application/json", "application/xml", "text/plain"}, registry.ContentTypes())
+}
+
+func TestText(t *testing.T) {
+	var (
+		text  string
+		bytes []byte
+	)
+
+	assert.NoError(t, codec.Text.Unmarshal([]byte("abc"), &text))
+	assert.Equal(t, "abc", text)
+	assert.NoError(t, codec.Text.Unmarshal([]byte("abc"), &bytes))
+	assert.Equal(t, []byte("abc"), bytes)
+	assert.EqualError(t, codec.Text.Unmarshal([]byte("1"), new(int)), "can't decode text into *int")
+
+	data, err := codec.Text.Marshal(42)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", string(data))
+}
+
+func TestDecodingTypes(t *testing.T) {
+	type row struct {
+		ID int `json:"id"`
+	}
+
+	var registry = codec.NewRegistry(codec.JSON, codec.Text, csv.Codec, protobuf.Codec)
+
+	tests := []struct {
+		name    string
+		pointer interface{}
+		want    []string
+	}{
+		{"structure", new(row), []string{"application/json"}},
+		{"rows", new([]row), []string{"application/json", "text/csv"}},
+		{"string", new(string), []string{"application/json", "text/plain"}},
+		{"text unmarshaler", new(net.IP), []string{"application/json", "text/plain"}},
+		{"message", new(wrapperspb.StringValue), []string{"application/json", "application/x-protobuf"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, registry.DecodingTypes(reflect.TypeOf(tt.pointer)), tt.name)
+	}
+}
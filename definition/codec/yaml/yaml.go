@@ -0,0 +1,30 @@
+// Package yaml - YAML codec.
+package yaml
+
+import (
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"gopkg.in/yaml.v3"
+)
+
+const ContentType = "application/yaml"
+
+// Codec - YAML codec for 'engi.WithCodecs'.
+var Codec = codec.New(ContentType, Marshal, Unmarshal)
+
+// Marshal - encodes value, fields of structures are named by 'yaml' tags (lowercased names by default).
+// Wrapped payload (e.g. of 'response.AsIs') is encoded without wrapper.
+func Marshal(value interface{}) ([]byte, error) {
+	return yaml.Marshal(codec.Unwrap(value))
+}
+
+// Unmarshal - decodes data into pointer, fields of structures are named by 'yaml' tags.
+// Can be used with 'parameter.CustomBody'.
+func Unmarshal(data []byte, pointer interface{}) error {
+	return yaml.Unmarshal(data, pointer)
+}
+
+// Marshaler - returns YAML marshaler for 'response.MarshalAs'.
+func Marshaler() response.Marshaler {
+	return response.AsCodec(Codec)
+}
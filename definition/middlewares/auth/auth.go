@@ -2,12 +2,15 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/kliuchnikovv/engi"
 	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/response"
 	"github.com/kliuchnikovv/engi/internal/routes"
@@ -24,9 +27,11 @@ const (
 var errUnathorized = errors.New("Unauthorized.")
 
 type Authorization struct {
-	name string
+	name   string
+	scheme *docs.SecurityScheme
 
-	handle func(context.Context, *http.Request, http.ResponseWriter) error
+	// handle - authorizes request and returns principal (identity of client).
+	handle func(context.Context, *http.Request, http.ResponseWriter) (string, error)
 }
 
 func (auth *Authorization) Bind(route *routes.Route) error {
@@ -36,11 +41,29 @@ func (auth *Authorization) Bind(route *routes.Route) error {
 }
 
 func (auth *Authorization) Handle(ctx context.Context, req *request.Request, resp *response.Response) error {
-	return auth.handle(ctx, req.GetRequest(), resp.ResponseWriter())
+	principal, err := auth.handle(ctx, req.GetRequest(), resp.ResponseWriter())
+	if err != nil {
+		return err
+	}
+
+	request.SetPrincipal(req, principal)
+
+	return nil
 }
 
 func (auth *Authorization) Docs(route *routes.Route) {
-	panic("not implemented")
+	if auth.scheme == nil {
+		return
+	}
+
+	if route.Operation.SecuritySchemes == nil {
+		route.Operation.SecuritySchemes = make(map[string]*docs.SecurityScheme)
+	}
+
+	route.Operation.SecuritySchemes[auth.name] = auth.scheme
+	route.Operation.Security = append(route.Operation.Security, map[string][]string{
+		auth.name: {},
+	})
 }
 
 func (auth *Authorization) Priority() int {
@@ -50,26 +73,27 @@ func (auth *Authorization) Priority() int {
 func NoAuth() engi.Middleware {
 	return &Authorization{
 		name: "no auth",
-		handle: func(_ context.Context, _ *http.Request, _ http.ResponseWriter) error {
-			return nil
+		handle: func(_ context.Context, _ *http.Request, _ http.ResponseWriter) (string, error) {
+			return "", nil
 		},
 	}
 }
 
 func Basic(username, password string) engi.Middleware {
 	return &Authorization{
-		name: "basic",
-		handle: func(_ context.Context, r *http.Request, w http.ResponseWriter) error {
+		name:   "basic",
+		scheme: &docs.SecurityScheme{Type: "http", Scheme: "basic"},
+		handle: func(_ context.Context, r *http.Request, w http.ResponseWriter) (string, error) {
 			gotUser, gotPassword, ok := r.BasicAuth()
 			if !ok {
-				return errUnathorized
+				return "", errUnathorized
 			}
 
 			if username != gotUser || password != gotPassword {
-				return errUnathorized
+				return "", errUnathorized
 			}
 
-			return nil
+			return gotUser, nil
 		},
 	}
 }
@@ -86,22 +110,24 @@ func BearerFunc(
 	isValid func(string) bool,
 ) engi.Middleware {
 	return &Authorization{
-		name: "bearer",
-		handle: func(_ context.Context, r *http.Request, w http.ResponseWriter) error {
+		name:   "bearer",
+		scheme: &docs.SecurityScheme{Type: "http", Scheme: "bearer"},
+		handle: func(_ context.Context, r *http.Request, w http.ResponseWriter) (string, error) {
 			var header = r.Header.Get(authHeader)
 			if len(header) == 0 {
 				w.WriteHeader(http.StatusUnauthorized)
 
-				return errUnathorized
+				return "", errUnathorized
 			}
 
-			if isValid(strings.TrimPrefix(authHeader, bearerPrefix)) {
+			var token = strings.TrimPrefix(header, bearerPrefix)
+			if !isValid(token) {
 				w.WriteHeader(http.StatusUnauthorized)
 
-				return errUnathorized
+				return "", errUnathorized
 			}
 
-			return nil
+			return identity(token), nil
 		},
 	}
 }
@@ -114,8 +140,9 @@ func APIKey(
 	}
 
 	return &Authorization{
-		name: "api key",
-		handle: func(_ context.Context, r *http.Request, w http.ResponseWriter) error {
+		name:   "api_key",
+		scheme: &docs.SecurityScheme{Type: "apiKey", Name: key, In: string(place)},
+		handle: func(_ context.Context, r *http.Request, w http.ResponseWriter) (string, error) {
 			var parameter string
 
 			switch place {
@@ -135,10 +162,18 @@ func APIKey(
 			if value != parameter {
 				w.WriteHeader(http.StatusUnauthorized)
 
-				return errUnathorized
+				return "", errUnathorized
 			}
 
-			return nil
+			return identity(parameter), nil
 		},
 	}
 }
+
+// identity - returns principal of client authorized by secret: truncated hash of it,
+// so secret isn't exposed by 'Principal' or used as is in keys of rate limit stores.
+func identity(secret string) string {
+	var sum = sha256.Sum256([]byte(secret))
+
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,233 @@
+// Package compress - compression of responses negotiated by 'Accept-Encoding' header.
+package compress
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+const (
+	Gzip    = "gzip"
+	Deflate = "deflate"
+	Brotli  = "br"
+	Zstd    = "zstd"
+
+	// DefaultMinSize - responses smaller than it aren't compressed by default.
+	DefaultMinSize = 1024
+)
+
+// DefaultContentTypes - types of responses compressed by default, '*' matches any subtype.
+var DefaultContentTypes = []string{
+	"text/*",
+	"application/json",
+	"application/*+json",
+	"application/x-ndjson",
+	"application/xml",
+	"application/*+xml",
+	"application/javascript",
+	"application/yaml",
+	"application/x-yaml",
+	"application/csv",
+	"image/svg+xml",
+}
+
+type (
+	// Option - configures compression middleware.
+	Option func(*Compressor)
+
+	// Compressor - middleware compressing responses with encoding client accepts.
+	// Responses smaller than minimal size, of types out of allow-list or already encoded are sent as is.
+	// Streamed responses (see 'Response.Stream' and 'engi.HandleSSE') are compressed and flushed as they're written.
+	Compressor struct {
+		encodings    []string
+		contentTypes []string
+		minSize      int
+	}
+)
+
+// Responses - compresses responses, can be used for whole engine (see 'engi.WithMiddlewares')
+// or for service (see 'engi.MiddlewaresAPI'), service's one overrides engine's one.
+// By default encodings are preferred in order: zstd, br, gzip, deflate.
+func Responses(options ...Option) engi.Middleware {
+	var compressor = Compressor{
+		encodings:    []string{Zstd, Brotli, Gzip, Deflate},
+		contentTypes: DefaultContentTypes,
+		minSize:      DefaultMinSize,
+	}
+
+	for _, option := range options {
+		option(&compressor)
+	}
+
+	return &compressor
+}
+
+// WithEncodings - sets supported encodings in order of preference,
+// preference is used if client accepts several encodings equally.
+//
+// Panics if encoding isn't supported.
+func WithEncodings(encodings ...string) Option {
+	for _, encoding := range encodings {
+		if _, ok := encoders[encoding]; !ok {
+			panic(fmt.Sprintf("unsupported encoding: '%s'", encoding))
+		}
+	}
+
+	return func(compressor *Compressor) {
+		compressor.encodings = encodings
+	}
+}
+
+// WithContentTypes - sets types of compressed responses, e.g. 'text/*' or 'application/json'.
+func WithContentTypes(contentTypes ...string) Option {
+	return func(compressor *Compressor) {
+		compressor.contentTypes = contentTypes
+	}
+}
+
+// WithMinSize - sets size of smallest compressed response in bytes.
+// Streamed responses are compressed if they're flushed before size is reached.
+func WithMinSize(bytes int) Option {
+	return func(compressor *Compressor) {
+		compressor.minSize = bytes
+	}
+}
+
+func (compressor *Compressor) Handle(_ context.Context, req *request.Request, resp *response.Response) error {
+	var header = resp.ResponseWriter().Header()
+
+	// Response depends on header even if it's not compressed.
+	if !slices.Contains(header.Values("Vary"), "Accept-Encoding") {
+		header.Add("Vary", "Accept-Encoding")
+	}
+
+	var encoding = compressor.negotiate(req.GetRequest().Header.Values("Accept-Encoding"))
+
+	// Nothing is written yet, so writer of engine's middleware is reconfigured by service's one.
+	if writer, ok := unwrap(resp.ResponseWriter()).(*compressWriter); ok {
+		writer.compressor, writer.encoding = compressor, encoding
+
+		return nil
+	}
+
+	if encoding == "" {
+		return nil
+	}
+
+	response.WrapWriter(resp, func(writer http.ResponseWriter) http.ResponseWriter {
+		return &compressWriter{
+			ResponseWriter: writer,
+			compressor:     compressor,
+			encoding:       encoding,
+		}
+	})
+
+	return nil
+}
+
+func (compressor *Compressor) Docs(*routes.Route) {}
+
+// Priority - writer is wrapped before any middleware could respond.
+func (compressor *Compressor) Priority() int {
+	return 0
+}
+
+// negotiate - returns supported encoding with highest quality in 'Accept-Encoding' values,
+// empty if client accepts none of them.
+func (compressor *Compressor) negotiate(values []string) string {
+	var (
+		qualities = make(map[string]float64)
+		wildcard  = -1.0
+	)
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			var (
+				params  = strings.Split(item, ";")
+				name    = strings.ToLower(strings.TrimSpace(params[0]))
+				quality = 1.0
+			)
+
+			for _, param := range params[1:] {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(strings.TrimSpace(key), "q") {
+					if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+						quality = parsed
+					}
+				}
+			}
+
+			if name == "*" {
+				wildcard = quality
+			} else if name != "" {
+				qualities[name] = quality
+			}
+		}
+	}
+
+	var (
+		result string
+		best   float64
+	)
+
+	for _, encoding := range compressor.encodings {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality = wildcard
+		}
+
+		if quality > best {
+			result, best = encoding, quality
+		}
+	}
+
+	return result
+}
+
+// compressible - reports whether responses of content type are compressed.
+func (compressor *Compressor) compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range compressor.contentTypes {
+		if matchType(strings.ToLower(allowed), mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchType - reports whether media type matches pattern with optional '*' in subtype,
+// e.g. 'text/*' or 'application/*+json'.
+func matchType(pattern, mediaType string) bool {
+	prefix, suffix, ok := strings.Cut(pattern, "*")
+	if !ok {
+		return pattern == mediaType
+	}
+
+	return len(mediaType) >= len(prefix)+len(suffix) &&
+		strings.HasPrefix(mediaType, prefix) &&
+		strings.HasSuffix(mediaType, suffix)
+}
+
+// unwrap - returns writer wrapped by response's one.
+func unwrap(writer http.ResponseWriter) http.ResponseWriter {
+	if unwrapper, ok := writer.(interface{ Unwrap() http.ResponseWriter }); ok {
+		return unwrapper.Unwrap()
+	}
+
+	return writer
+}
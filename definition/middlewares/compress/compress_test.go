@@ -0,0 +1,292 @@
+package compress_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/middlewares/compress"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+type catalogService struct {
+	// next - lets event stream send its next event.
+	next chan struct{}
+}
+
+func (s *catalogService) Prefix() string {
+	return "catalog"
+}
+
+func (s *catalogService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET("list"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				var items = make([]item, 200)
+				for i := range items {
+					items[i].Name = "item"
+				}
+
+				return resp.WithETag("v7").OK(items)
+			},
+		),
+		engi.PUT("list"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.NoContent()
+			},
+			validate.Preconditions(func(context.Context, engi.Request) (string, time.Time, error) {
+				return "v7", time.Time{}, nil
+			}),
+		),
+		engi.GET("small"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.OK(item{Name: "small"})
+			},
+		),
+		engi.GET("image"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.Reader(strings.NewReader("\x89PNG\x0d\x0a\x1a\x0a" + strings.Repeat("\x00", 2048)))
+			},
+		),
+		engi.GET("events"): engi.HandleSSE(
+			func(_ context.Context, _ engi.Request, events engi.SSEWriter) error {
+				for i := 0; i < 2; i++ {
+					if err := events.Data("tick"); err != nil {
+						return err
+					}
+
+					<-s.next
+				}
+
+				return nil
+			},
+		),
+	}
+}
+
+// gzipService - service preferring only gzip over engine's settings.
+type gzipService struct {
+	catalogService
+}
+
+func (s *gzipService) Prefix() string {
+	return "gzip"
+}
+
+func (s *gzipService) Middlewares() []engi.Middleware {
+	return []engi.Middleware{
+		compress.Responses(compress.WithEncodings(compress.Gzip)),
+	}
+}
+
+func decode(t *testing.T, encoding string, body io.Reader) string {
+	var (
+		reader io.Reader
+		err    error
+	)
+
+	switch encoding {
+	case "gzip":
+		reader, err = gzip.NewReader(body)
+	case "deflate":
+		reader, err = zlib.NewReader(body)
+	case "br":
+		reader = brotli.NewReader(body)
+	case "zstd":
+		reader, err = zstd.NewReader(body)
+	default:
+		reader = body
+	}
+
+	assert.NoError(t, err)
+
+	result, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+
+	return string(result)
+}
+
+func TestResponses(t *testing.T) {
+	var engine = engi.New("", engi.WithMiddlewares(compress.Responses()))
+
+	assert.NoError(t, engine.RegisterServices(&catalogService{}, &gzipService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantEncoding   string
+	}{
+		{"preferred", "/catalog/list", "gzip, deflate, zstd", "zstd"},
+		{"gzip", "/catalog/list", "gzip", "gzip"},
+		{"brotli", "/catalog/list", "gzip;q=0.8, br", "br"},
+		{"quality", "/catalog/list", "gzip;q=0.5, deflate", "deflate"},
+		{"wildcard", "/catalog/list", "*", "zstd"},
+		{"excluded", "/catalog/list", "*, zstd;q=0", "br"},
+		{"unsupported", "/catalog/list", "compress", ""},
+		{"none", "/catalog/list", "", ""},
+		{"small", "/catalog/small", "gzip", ""},
+		{"not compressible", "/catalog/image", "gzip", ""},
+		{"service settings", "/gzip/list", "zstd, gzip", "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			assert.NoError(t, err)
+
+			// Transport doesn't decode responses if header is set explicitly.
+			request.Header.Set("Accept-Encoding", tt.acceptEncoding)
+
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, tt.wantEncoding, resp.Header.Get("Content-Encoding"))
+			assert.Contains(t, resp.Header.Values("Vary"), "Accept-Encoding")
+
+			var body = decode(t, tt.wantEncoding, resp.Body)
+
+			switch tt.path {
+			case "/catalog/image":
+				assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+				assert.Len(t, body, 2056)
+			case "/catalog/small":
+				assert.Equal(t, `{"name":"small"}`, body)
+			default:
+				assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+				assert.True(t, strings.HasPrefix(body, `[{"name":"item"},`), body)
+			}
+		})
+	}
+}
+
+func TestResponsesPreconditions(t *testing.T) {
+	var engine = engi.New("", engi.WithMiddlewares(compress.Responses()))
+
+	assert.NoError(t, engine.RegisterServices(&catalogService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/catalog/list", nil)
+	assert.NoError(t, err)
+
+	request.Header.Set("Accept-Encoding", "gzip")
+
+	resp, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	// Compressed representation has own strong tag.
+	var etag = resp.Header.Get("ETag")
+	assert.Equal(t, `"v7-gzip"`, etag)
+
+	tests := []struct {
+		name       string
+		method     string
+		header     string
+		etag       string
+		wantStatus int
+	}{
+		{"not modified", http.MethodGet, "If-None-Match", etag, http.StatusNotModified},
+		{"match", http.MethodPut, "If-Match", etag, http.StatusNoContent},
+		{"match original", http.MethodPut, "If-Match", `"v7"`, http.StatusNoContent},
+		{"changed", http.MethodPut, "If-Match", `"v6-gzip"`, http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		request, err := http.NewRequest(tt.method, server.URL+"/catalog/list", nil)
+		assert.NoError(t, err)
+
+		request.Header.Set("Accept-Encoding", "gzip")
+		request.Header.Set(tt.header, tt.etag)
+
+		resp, err := http.DefaultClient.Do(request)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+	}
+}
+
+func TestResponsesStreaming(t *testing.T) {
+	var (
+		service = &catalogService{next: make(chan struct{})}
+		engine  = engi.New("", engi.WithMiddlewares(compress.Responses()))
+	)
+
+	assert.NoError(t, engine.RegisterServices(service))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		encoding string
+		reader   func(io.Reader) (io.Reader, error)
+	}{
+		{"gzip", func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{"br", func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, server.URL+"/catalog/events", nil)
+			assert.NoError(t, err)
+
+			request.Header.Set("Accept-Encoding", tt.encoding)
+
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.encoding, resp.Header.Get("Content-Encoding"))
+			assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+			reader, err := tt.reader(resp.Body)
+			assert.NoError(t, err)
+
+			// Events are flushed through encoder while handler is still sending them.
+			var lines = bufio.NewReader(reader)
+
+			for i := 0; i < 2; i++ {
+				line, err := lines.ReadString('\n')
+				assert.NoError(t, err)
+				assert.Equal(t, "data: tick\n", line)
+
+				line, err = lines.ReadString('\n')
+				assert.NoError(t, err)
+				assert.Equal(t, "\n", line)
+
+				service.next <- struct{}{}
+			}
+
+			rest, err := io.ReadAll(lines)
+			assert.NoError(t, err)
+			assert.Empty(t, rest)
+		})
+	}
+
+	assert.Panics(t, func() { compress.WithEncodings("compress") })
+}
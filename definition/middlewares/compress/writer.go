@@ -0,0 +1,229 @@
+package compress
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/kliuchnikovv/engi/internal/response"
+)
+
+type encoder interface {
+	io.WriteCloser
+
+	Flush() error
+	Reset(io.Writer)
+}
+
+// encoders - pools of encoders by name of encoding.
+var encoders = map[string]*sync.Pool{
+	Gzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+	Deflate: {New: func() any {
+		return zlib.NewWriter(nil)
+	}},
+	Brotli: {New: func() any {
+		return brotli.NewWriterLevel(nil, brotli.DefaultCompression)
+	}},
+	Zstd: {New: func() any {
+		encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+
+		return encoder
+	}},
+}
+
+type state int
+
+const (
+	// undecided - data is buffered until it's known whether response should be compressed.
+	undecided state = iota
+	compressing
+	passing
+)
+
+// compressWriter - writer compressing response if it's big enough and of compressible type.
+type compressWriter struct {
+	http.ResponseWriter
+
+	compressor *Compressor
+	encoding   string
+
+	state   state
+	status  int
+	buffer  []byte
+	encoder encoder
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if w.state != undecided {
+		w.ResponseWriter.WriteHeader(code)
+
+		return
+	}
+
+	// Informational responses don't finish headers.
+	if code < http.StatusOK {
+		w.ResponseWriter.WriteHeader(code)
+
+		return
+	}
+
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	switch w.state {
+	case compressing:
+		return w.encoder.Write(p)
+	case passing:
+		return w.ResponseWriter.Write(p)
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.buffer = append(w.buffer, p...)
+
+	if !w.compressible() {
+		return len(p), w.pass()
+	}
+
+	if len(w.buffer) >= w.compressor.minSize {
+		return len(p), w.compress()
+	}
+
+	return len(p), nil
+}
+
+// FlushError - sends buffered data, streamed response is compressed regardless of its size.
+func (w *compressWriter) FlushError() error {
+	if w.state == undecided && w.status != 0 {
+		var err error
+
+		if w.compressible() {
+			err = w.compress()
+		} else {
+			err = w.pass()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.state == compressing {
+		if err := w.encoder.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap - returns original writer, used by http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close - sends response buffered because of its small size or finishes compressed one.
+func (w *compressWriter) Close() error {
+	switch w.state {
+	case undecided:
+		// Nothing was written, e.g. connection was hijacked.
+		if w.status == 0 {
+			return nil
+		}
+
+		return w.pass()
+	case compressing:
+		var err = w.encoder.Close()
+
+		w.encoder.Reset(nil)
+		encoders[w.encoding].Put(w.encoder)
+		w.encoder = nil
+		w.state = passing
+
+		return err
+	default:
+		return nil
+	}
+}
+
+// compressible - reports whether response can be compressed, content type is detected if it's not set.
+func (w *compressWriter) compressible() bool {
+	var header = w.Header()
+
+	if w.encoding == "" ||
+		header.Get("Content-Encoding") != "" ||
+		header.Get("Content-Range") != "" ||
+		w.status == http.StatusNoContent ||
+		w.status == http.StatusNotModified ||
+		w.status == http.StatusPartialContent {
+		return false
+	}
+
+	var contentType = header.Get("Content-Type")
+
+	// Type of compressed data can't be detected by server, so it's detected from original one.
+	if contentType == "" && len(w.buffer) > 0 {
+		contentType = http.DetectContentType(w.buffer)
+		header.Set("Content-Type", contentType)
+	}
+
+	return w.compressor.compressible(contentType)
+}
+
+// compress - sends headers of compressed response and buffered data through encoder.
+func (w *compressWriter) compress() error {
+	var header = w.Header()
+
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", w.encoding)
+
+	// Compressed representation isn't byte-equal to original one, so it gets own strong tag.
+	if etag := header.Get("ETag"); etag != "" {
+		header.Set("ETag", response.EncodedETag(etag, w.encoding))
+	}
+
+	w.state = compressing
+	w.encoder = encoders[w.encoding].Get().(encoder)
+	w.encoder.Reset(w.ResponseWriter)
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	return w.flushBuffer(w.encoder)
+}
+
+// pass - sends headers and buffered data as is.
+func (w *compressWriter) pass() error {
+	w.state = passing
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	return w.flushBuffer(w.ResponseWriter)
+}
+
+func (w *compressWriter) flushBuffer(writer io.Writer) error {
+	if len(w.buffer) == 0 {
+		return nil
+	}
+
+	var _, err = writer.Write(w.buffer)
+
+	w.buffer = nil
+
+	if errors.Is(err, http.ErrBodyNotAllowed) {
+		return nil
+	}
+
+	return err
+}
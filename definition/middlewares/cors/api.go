@@ -11,6 +11,7 @@ var (
 	ErrMissingConanicalHeader     = errors.New("missing canonical header")
 	ErrCORSMethodHeaderNotFound   = errors.New("CORS-Method header not found")
 	ErrCORSMethodHeaderNotAllowed = errors.New("CORS-Method header not allowed")
+	ErrCredentialsWithAnyOrigin   = errors.New("credentials can't be allowed for any origin")
 )
 
 func AllowedHeaders(headers ...string) engi.Middleware {
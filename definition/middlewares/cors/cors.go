@@ -19,7 +19,7 @@ func (origins corsAllowedOrigins) Handle(ctx context.Context, req *request.Reque
 		origin = r.Header.Get(corsOriginHeader)
 	)
 
-	if !contains(origins, origin) && !contains(origins, corsOriginMatchAll) {
+	if !Origins(origins).Allows(origin) {
 		return resp.Forbidden("%s: '%s'", ErrOriginNotAllowed, origin)
 	}
 
@@ -28,10 +28,13 @@ func (origins corsAllowedOrigins) Handle(ctx context.Context, req *request.Reque
 	return nil
 }
 
-func (origins corsAllowedOrigins) Docs(*routes.Route) {
-	panic("unimplemented")
+func (origins corsAllowedOrigins) Preflight(ctx context.Context, req *request.Request, resp *response.Response) error {
+	return origins.Handle(ctx, req, resp)
 }
 
+// Docs - OpenAPI has no field for origins, browsers learn them from preflight.
+func (origins corsAllowedOrigins) Docs(*routes.Route) {}
+
 func (origins corsAllowedOrigins) Priority() int {
 	return 10
 }
@@ -71,10 +74,13 @@ func (headers corsAllowedHeaders) Handle(ctx context.Context, req *request.Reque
 	return nil
 }
 
-func (headers corsAllowedHeaders) Docs(*routes.Route) {
-	panic("unimplemented")
+func (headers corsAllowedHeaders) Preflight(ctx context.Context, req *request.Request, resp *response.Response) error {
+	return headers.Handle(ctx, req, resp)
 }
 
+// Docs - headers are checked only in preflight, they aren't operation's parameters.
+func (headers corsAllowedHeaders) Docs(*routes.Route) {}
+
 func (headers corsAllowedHeaders) Priority() int {
 	return 11
 }
@@ -84,8 +90,9 @@ type corsAllowedMethods []string
 func (methods corsAllowedMethods) Handle(ctx context.Context, req *request.Request, resp *response.Response) error {
 	var r = req.GetRequest()
 
-	if _, ok := r.Header[corsRequestMethodHeader]; !ok {
-		return resp.BadRequest(ErrCORSMethodHeaderNotFound.Error())
+	// Only preflight requests are checked, others are regular requests.
+	if !isPreflight(r) {
+		return nil
 	}
 
 	method := r.Header.Get(corsRequestMethodHeader)
@@ -96,10 +103,13 @@ func (methods corsAllowedMethods) Handle(ctx context.Context, req *request.Reque
 	return nil
 }
 
-func (methods corsAllowedMethods) Docs(*routes.Route) {
-	panic("unimplemented")
+func (methods corsAllowedMethods) Preflight(ctx context.Context, req *request.Request, resp *response.Response) error {
+	return methods.Handle(ctx, req, resp)
 }
 
+// Docs - 'OPTIONS' requests are answered automatically, so they aren't added as operations.
+func (methods corsAllowedMethods) Docs(*routes.Route) {}
+
 func (methods corsAllowedMethods) Priority() int {
 	return 12 // TODO: make external priority map
 }
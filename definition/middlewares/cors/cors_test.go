@@ -248,11 +248,11 @@ func TestCorsAllowedMethods_Handle(t *testing.T) {
 		wantStatus    string
 	}{
 		{
-			name:          "missing header returns bad request",
+			name:          "non-preflight request returns nil",
 			methods:       corsAllowedMethods{"GET", "POST"},
 			headerValue:   "",
 			headerPresent: false,
-			wantStatus:    "Bad Request",
+			wantStatus:    "",
 		},
 		{
 			name:          "method not allowed returns method not allowed",
@@ -277,6 +277,7 @@ func TestCorsAllowedMethods_Handle(t *testing.T) {
 				header.Set(corsRequestMethodHeader, tt.headerValue)
 			}
 			req := request.New(&http.Request{
+				Method: corsOptionMethod,
 				Header: header,
 			})
 			writer := &mockWriter{header: http.Header{}}
@@ -0,0 +1,36 @@
+package cors
+
+import "strings"
+
+// Origins - allow-list of origins (e.g. 'https://example.com').
+// Special value '*' allows any origin,
+// wildcard subdomain (e.g. 'https://*.example.com') allows any subdomain of domain.
+type Origins []string
+
+// Allows - checks if origin is in allow-list.
+// NOTE: empty list allows any origin.
+func (origins Origins) Allows(origin string) bool {
+	if contains(origins, origin) || contains(origins, corsOriginMatchAll) {
+		return true
+	}
+
+	for _, allowed := range origins {
+		prefix, suffix, found := strings.Cut(allowed, corsSubdomainMatchAll)
+		if !found {
+			continue
+		}
+
+		if len(origin) > len(prefix)+len(suffix)+1 &&
+			strings.HasPrefix(origin, prefix) &&
+			strings.HasSuffix(origin, "."+suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchAll - checks if any origin allowed with '*'.
+func (origins Origins) MatchAll() bool {
+	return len(origins) == 0 || contains(origins, corsOriginMatchAll)
+}
@@ -0,0 +1,151 @@
+package cors
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+// Policy - complete CORS middleware.
+// Preflight requests are answered automatically for every path policy applied to,
+// so there is no need to register 'OPTIONS' routes.
+//
+// Policy can be set engine-wide using 'engi.WithMiddlewares',
+// per service using 'Middlewares' method or per route.
+type Policy struct {
+	// AllowedOrigins - origins allowed to make cross-origin requests, empty list allows any origin.
+	AllowedOrigins Origins
+	// AllowedMethods - methods allowed in preflight requests, empty list allows any method.
+	AllowedMethods []string
+	// AllowedHeaders - headers allowed in preflight requests, empty list allows any header.
+	AllowedHeaders []string
+	// ExposedHeaders - headers that browser allowed to expose to client.
+	ExposedHeaders []string
+	// AllowCredentials - allows requests with cookies and authorization headers.
+	// Origins should be listed explicitly, route with policy allowing credentials for any origin isn't registered.
+	AllowCredentials bool
+	// MaxAge - how long results of preflight request can be cached, zero omits header.
+	MaxAge time.Duration
+}
+
+// Bind - checks that credentials aren't allowed for any origin:
+// any site could read responses to requests made with user's cookies.
+func (policy Policy) Bind(*routes.Route) error {
+	if policy.AllowCredentials && policy.AllowedOrigins.MatchAll() {
+		return ErrCredentialsWithAnyOrigin
+	}
+
+	return nil
+}
+
+func (policy Policy) Handle(ctx context.Context, req *request.Request, resp *response.Response) error {
+	var r = req.GetRequest()
+
+	if isPreflight(r) {
+		return policy.Preflight(ctx, req, resp)
+	}
+
+	var (
+		w      = resp.ResponseWriter()
+		origin = r.Header.Get(corsOriginHeader)
+	)
+
+	w.Header().Add(corsVaryHeader, corsOriginHeader)
+
+	// Not a cross-origin request.
+	if origin == "" {
+		return nil
+	}
+
+	if !policy.AllowedOrigins.Allows(origin) {
+		return resp.Forbidden("%s: '%s'", ErrOriginNotAllowed, origin)
+	}
+
+	policy.setOrigin(w, origin)
+
+	if len(policy.ExposedHeaders) > 0 {
+		w.Header().Set(corsExposeHeadersHeader, strings.Join(policy.ExposedHeaders, ","))
+	}
+
+	return nil
+}
+
+// Preflight - sets preflight response headers, response itself written by route.
+func (policy Policy) Preflight(_ context.Context, req *request.Request, resp *response.Response) error {
+	var (
+		r      = req.GetRequest()
+		w      = resp.ResponseWriter()
+		origin = r.Header.Get(corsOriginHeader)
+		method = r.Header.Get(corsRequestMethodHeader)
+	)
+
+	w.Header().Add(corsVaryHeader, corsOriginHeader)
+	w.Header().Add(corsVaryHeader, corsRequestMethodHeader)
+	w.Header().Add(corsVaryHeader, corsRequestHeadersHeader)
+
+	if !policy.AllowedOrigins.Allows(origin) {
+		return resp.Forbidden("%s: '%s'", ErrOriginNotAllowed, origin)
+	}
+
+	if !contains(policy.AllowedMethods, method) {
+		return resp.MethodNotAllowed(ErrCORSMethodHeaderNotAllowed.Error())
+	}
+
+	var allowedHeaders []string
+
+	for _, header := range strings.Split(r.Header.Get(corsRequestHeadersHeader), ",") {
+		canonicalHeader := http.CanonicalHeaderKey(strings.TrimSpace(header))
+		if canonicalHeader == "" {
+			continue
+		}
+
+		if !contains(policy.AllowedHeaders, canonicalHeader) && !contains(defaultCorsHeaders, canonicalHeader) {
+			return resp.Forbidden(ErrMissingConanicalHeader.Error())
+		}
+
+		allowedHeaders = append(allowedHeaders, canonicalHeader)
+	}
+
+	policy.setOrigin(w, origin)
+
+	w.Header().Set(corsAllowMethodsHeader, method)
+
+	if len(allowedHeaders) > 0 {
+		w.Header().Set(corsAllowHeadersHeader, strings.Join(allowedHeaders, ","))
+	}
+
+	if policy.MaxAge > 0 {
+		w.Header().Set(corsMaxAgeHeader, strconv.Itoa(int(policy.MaxAge.Seconds())))
+	}
+
+	return nil
+}
+
+// Docs - policy only sets 'Access-Control-*' response headers, nothing to describe in operation.
+func (policy Policy) Docs(*routes.Route) {}
+
+func (policy Policy) Priority() int {
+	return 10
+}
+
+// setOrigin - sets allowed origin and credentials headers.
+// Any origin is allowed only with wildcard, credentials are never allowed for it (see 'Bind').
+func (policy Policy) setOrigin(w http.ResponseWriter, origin string) {
+	if policy.AllowedOrigins.MatchAll() {
+		w.Header().Set(corsAllowOriginHeader, corsOriginMatchAll)
+
+		return
+	}
+
+	w.Header().Set(corsAllowOriginHeader, origin)
+
+	if policy.AllowCredentials {
+		w.Header().Set(corsAllowCredentials, "true")
+	}
+}
@@ -0,0 +1,144 @@
+package cors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+	"github.com/kliuchnikovv/engi/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy(t *testing.T) {
+	var (
+		policy = Policy{
+			AllowedOrigins:   Origins{"https://*.example.com"},
+			AllowedMethods:   []string{http.MethodGet},
+			AllowedHeaders:   []string{"X-Token"},
+			ExposedHeaders:   []string{"X-Total"},
+			AllowCredentials: true,
+			MaxAge:           time.Minute,
+		}
+		router = routes.New()
+	)
+
+	_, err := router.Add(http.MethodGet, "notes", func(_ context.Context, _ *request.Request, resp *response.Response) error {
+		return resp.OK("notes")
+	}, types.NewJSONMarshaler(), new(types.ResponseAsIs), nil, policy)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		method     string
+		headers    map[string]string
+		wantStatus int
+		wantHeader map[string]string
+	}{
+		{
+			name:   "preflight answered automatically",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				corsOriginHeader:         "https://app.example.com",
+				corsRequestMethodHeader:  http.MethodGet,
+				corsRequestHeadersHeader: "x-token",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeader: map[string]string{
+				corsAllowOriginHeader:  "https://app.example.com",
+				corsAllowMethodsHeader: http.MethodGet,
+				corsAllowHeadersHeader: "X-Token",
+				corsAllowCredentials:   "true",
+				corsMaxAgeHeader:       "60",
+			},
+		},
+		{
+			name:   "preflight with not allowed method",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				corsOriginHeader:        "https://app.example.com",
+				corsRequestMethodHeader: http.MethodPost,
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "preflight from not allowed origin",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				corsOriginHeader:        "https://example.org",
+				corsRequestMethodHeader: http.MethodGet,
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "cross-origin request",
+			method: http.MethodGet,
+			headers: map[string]string{
+				corsOriginHeader: "https://app.example.com",
+			},
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{
+				corsAllowOriginHeader:   "https://app.example.com",
+				corsExposeHeadersHeader: "X-Total",
+				corsVaryHeader:          corsOriginHeader,
+			},
+		},
+		{
+			name:       "same-origin request",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			wantHeader: map[string]string{
+				corsAllowOriginHeader: "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				r      = httptest.NewRequest(tt.method, "/notes", nil)
+				writer = httptest.NewRecorder()
+			)
+
+			for key, value := range tt.headers {
+				r.Header.Set(key, value)
+			}
+
+			assert.NoError(t, router.Handle(context.Background(), request.New(r), writer, tt.method, "notes"))
+			assert.Equal(t, tt.wantStatus, writer.Code)
+
+			for key, value := range tt.wantHeader {
+				assert.Equal(t, value, writer.Header().Get(key), key)
+			}
+		})
+	}
+}
+
+func TestPolicyCredentials(t *testing.T) {
+	var router = routes.New()
+
+	for _, origins := range []Origins{nil, {"*"}, {"https://example.com", "*"}} {
+		_, err := router.Add(http.MethodGet, "notes", func(_ context.Context, _ *request.Request, resp *response.Response) error {
+			return resp.OK("notes")
+		}, types.NewJSONMarshaler(), new(types.ResponseAsIs), nil, Policy{
+			AllowedOrigins:   origins,
+			AllowCredentials: true,
+		})
+		assert.ErrorIs(t, err, ErrCredentialsWithAnyOrigin, origins)
+	}
+}
+
+func TestOrigins_Allows(t *testing.T) {
+	var origins = Origins{"https://example.com", "https://*.example.org"}
+
+	assert.True(t, origins.Allows("https://example.com"))
+	assert.True(t, origins.Allows("https://a.example.org"))
+	assert.True(t, origins.Allows("https://a.b.example.org"))
+	assert.False(t, origins.Allows("https://example.org"))
+	assert.False(t, origins.Allows("http://a.example.org"))
+	assert.False(t, origins.Allows("https://evilexample.org"))
+}
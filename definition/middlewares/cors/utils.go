@@ -4,9 +4,15 @@ import "net/http"
 
 const (
 	corsOriginMatchAll       string = "*"
+	corsSubdomainMatchAll    string = "*."
 	corsOriginHeader         string = "Origin"
+	corsVaryHeader           string = "Vary"
 	corsAllowOriginHeader    string = "Access-Control-Allow-Origin"
 	corsAllowHeadersHeader   string = "Access-Control-Allow-Headers"
+	corsAllowMethodsHeader   string = "Access-Control-Allow-Methods"
+	corsAllowCredentials     string = "Access-Control-Allow-Credentials"
+	corsExposeHeadersHeader  string = "Access-Control-Expose-Headers"
+	corsMaxAgeHeader         string = "Access-Control-Max-Age"
 	corsRequestMethodHeader  string = "Access-Control-Request-Method"
 	corsRequestHeadersHeader string = "Access-Control-Request-Headers"
 	corsOptionMethod         string = http.MethodOptions
@@ -29,3 +35,8 @@ func contains(slice []string, item string) bool {
 
 	return false
 }
+
+// isPreflight - checks if request is CORS preflight request.
+func isPreflight(r *http.Request) bool {
+	return r.Method == corsOptionMethod && r.Header.Get(corsRequestMethodHeader) != ""
+}
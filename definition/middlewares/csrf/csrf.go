@@ -0,0 +1,302 @@
+package csrf
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/middlewares/cors"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/docs"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+const (
+	defaultCookieName  = "csrf_token"
+	defaultHeaderName  = "X-CSRF-Token"
+	defaultFieldName   = "csrf_token"
+	defaultSessionName = "csrf_session"
+
+	originHeader  = "Origin"
+	refererHeader = "Referer"
+)
+
+var (
+	ErrOriginNotAllowed = errors.New("origin is not allowed")
+	ErrTokenNotFound    = errors.New("CSRF token not found")
+	ErrTokenMismatch    = errors.New("CSRF token mismatch")
+	ErrSessionNotFound  = errors.New("CSRF session not found")
+)
+
+type (
+	// Option - configures CSRF middleware.
+	Option func(*Protection)
+
+	// Protection - CSRF protection middleware.
+	Protection struct {
+		cookieName  string
+		sessionName string
+		headerName  string
+		fieldName   string
+		origins     cors.Origins
+
+		// issue - returns token for current request creating new one if needed.
+		issue func(*http.Request, http.ResponseWriter) (string, error)
+		// expected - returns token that should be sent by client.
+		expected func(*http.Request) (string, error)
+	}
+)
+
+// DoubleSubmit - protects route using double-submit cookie pattern:
+// token is sent in cookie and client must repeat it in header or form field.
+func DoubleSubmit(options ...Option) engi.Middleware {
+	var protection = newProtection(options...)
+
+	protection.issue = func(r *http.Request, w http.ResponseWriter) (string, error) {
+		if cookie, err := r.Cookie(protection.cookieName); err == nil && cookie.Value != "" {
+			return cookie.Value, nil
+		}
+
+		token, err := newToken()
+		if err != nil {
+			return "", err
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     protection.cookieName,
+			Value:    token,
+			Path:     "/",
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		return token, nil
+	}
+	protection.expected = func(r *http.Request) (string, error) {
+		cookie, err := r.Cookie(protection.cookieName)
+		if err != nil || cookie.Value == "" {
+			return "", ErrTokenNotFound
+		}
+
+		return cookie.Value, nil
+	}
+
+	return protection
+}
+
+// Synchronizer - protects route using synchronizer token pattern:
+// token is kept in 'store' by session and client must send it in header or form field.
+// Session is identified by cookie, name can be changed using 'SessionCookie' option.
+func Synchronizer(store Store, options ...Option) engi.Middleware {
+	var protection = newProtection(options...)
+
+	protection.issue = func(r *http.Request, w http.ResponseWriter) (string, error) {
+		var session string
+
+		if cookie, err := r.Cookie(protection.sessionName); err == nil && cookie.Value != "" {
+			session = cookie.Value
+
+			if token, ok := store.Get(session); ok {
+				return token, nil
+			}
+		} else {
+			if session, err = newToken(); err != nil {
+				return "", err
+			}
+
+			http.SetCookie(w, &http.Cookie{
+				Name:     protection.sessionName,
+				Value:    session,
+				Path:     "/",
+				Secure:   r.TLS != nil,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		token, err := newToken()
+		if err != nil {
+			return "", err
+		}
+
+		store.Set(session, token)
+
+		return token, nil
+	}
+	protection.expected = func(r *http.Request) (string, error) {
+		cookie, err := r.Cookie(protection.sessionName)
+		if err != nil || cookie.Value == "" {
+			return "", ErrSessionNotFound
+		}
+
+		token, ok := store.Get(cookie.Value)
+		if !ok {
+			return "", ErrTokenNotFound
+		}
+
+		return token, nil
+	}
+
+	return protection
+}
+
+// CookieName - sets name of cookie containing token (double-submit pattern).
+func CookieName(name string) Option {
+	return func(protection *Protection) {
+		protection.cookieName = name
+	}
+}
+
+// SessionCookie - sets name of cookie containing session (synchronizer pattern).
+func SessionCookie(name string) Option {
+	return func(protection *Protection) {
+		protection.sessionName = name
+	}
+}
+
+// HeaderName - sets name of header containing token sent by client.
+func HeaderName(name string) Option {
+	return func(protection *Protection) {
+		protection.headerName = name
+	}
+}
+
+// FieldName - sets name of form field containing token sent by client.
+func FieldName(name string) Option {
+	return func(protection *Protection) {
+		protection.fieldName = name
+	}
+}
+
+// TrustedOrigins - sets origins allowed to send unsafe requests.
+// By default only requests from the same host are allowed.
+func TrustedOrigins(origins ...string) Option {
+	return func(protection *Protection) {
+		protection.origins = cors.Origins(origins)
+	}
+}
+
+func newProtection(options ...Option) *Protection {
+	var protection = Protection{
+		cookieName:  defaultCookieName,
+		sessionName: defaultSessionName,
+		headerName:  defaultHeaderName,
+		fieldName:   defaultFieldName,
+	}
+
+	for _, option := range options {
+		option(&protection)
+	}
+
+	return &protection
+}
+
+func (protection *Protection) Handle(ctx context.Context, req *request.Request, resp *response.Response) error {
+	var (
+		r = req.GetRequest()
+		w = resp.ResponseWriter()
+	)
+
+	if isSafeMethod(r.Method) {
+		token, err := protection.issue(r, w)
+		if err != nil {
+			return resp.InternalServerError(err.Error())
+		}
+
+		request.SetCSRFToken(req, token)
+
+		return nil
+	}
+
+	if !protection.allowsOrigin(r) {
+		return resp.Forbidden(ErrOriginNotAllowed.Error())
+	}
+
+	expected, err := protection.expected(r)
+	if err != nil {
+		return resp.Forbidden(err.Error())
+	}
+
+	got, err := protection.submitted(req)
+	if errors.Is(err, request.ErrBodyTooLarge) {
+		return resp.RequestEntityTooLarge(err.Error())
+	}
+
+	if err != nil {
+		return resp.Forbidden(err.Error())
+	}
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(got)) != 1 {
+		return resp.Forbidden(ErrTokenMismatch.Error())
+	}
+
+	request.SetCSRFToken(req, expected)
+
+	return nil
+}
+
+func (protection *Protection) Docs(route *routes.Route) {
+	route.Operation.AddParameter(&docs.Parameter{
+		Name:        protection.headerName,
+		In:          string(placing.InHeader),
+		Description: "CSRF token, not required for safe methods",
+		Schema:      &docs.Schema{Type: "string"},
+	})
+}
+
+func (protection *Protection) Priority() int {
+	return 15
+}
+
+// allowsOrigin - checks 'Origin' header (or 'Referer' if there is no origin) against trusted origins.
+// Request without both headers is allowed, it will be checked by token.
+func (protection *Protection) allowsOrigin(r *http.Request) bool {
+	var origin = r.Header.Get(originHeader)
+
+	if origin == "" {
+		referer, err := url.Parse(r.Header.Get(refererHeader))
+		if err != nil {
+			return false
+		}
+
+		if referer.Host == "" {
+			return true
+		}
+
+		origin = referer.Scheme + "://" + referer.Host
+	}
+
+	if len(protection.origins) != 0 {
+		return protection.origins.Allows(origin)
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return parsed.Host == r.Host
+}
+
+// submitted - returns token sent by client in header or form field.
+func (protection *Protection) submitted(req *request.Request) (string, error) {
+	if token := req.GetRequest().Header.Get(protection.headerName); token != "" {
+		return token, nil
+	}
+
+	token, err := formValue(req, protection.fieldName)
+	if err != nil {
+		return "", err
+	}
+
+	if token == "" {
+		return "", ErrTokenNotFound
+	}
+
+	return token, nil
+}
@@ -0,0 +1,197 @@
+package csrf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoubleSubmit_Handle(t *testing.T) {
+	const token = "token"
+
+	tests := []struct {
+		name       string
+		method     string
+		origin     string
+		cookie     string
+		header     string
+		form       string
+		limit      int64
+		wantStatus int
+	}{
+		{
+			name:       "safe method issues token",
+			method:     http.MethodGet,
+			wantStatus: 0,
+		},
+		{
+			name:       "matching header token",
+			method:     http.MethodPost,
+			cookie:     token,
+			header:     token,
+			wantStatus: 0,
+		},
+		{
+			name:       "matching form token",
+			method:     http.MethodPost,
+			cookie:     token,
+			form:       defaultFieldName + "=" + token,
+			wantStatus: 0,
+		},
+		{
+			name:       "form too large",
+			method:     http.MethodPost,
+			cookie:     token,
+			form:       "comment=" + strings.Repeat("a", 64) + "&" + defaultFieldName + "=" + token,
+			limit:      32,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "missing cookie",
+			method:     http.MethodPost,
+			header:     token,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "token mismatch",
+			method:     http.MethodDelete,
+			cookie:     token,
+			header:     "other",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "foreign origin",
+			method:     http.MethodPost,
+			origin:     "https://evil.com",
+			cookie:     token,
+			header:     token,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "same origin",
+			method:     http.MethodPost,
+			origin:     "https://example.com",
+			cookie:     token,
+			header:     token,
+			wantStatus: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r = httptest.NewRequest(tt.method, "https://example.com/", strings.NewReader(tt.form))
+
+			if tt.origin != "" {
+				r.Header.Set(originHeader, tt.origin)
+			}
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: defaultCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(defaultHeaderName, tt.header)
+			}
+			if tt.form != "" {
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+
+			var (
+				req    = request.New(r)
+				writer = httptest.NewRecorder()
+				resp   = response.New(writer, types.NewJSONMarshaler(), &types.ResponseAsIs{})
+			)
+
+			request.SetMaxBodySize(req, tt.limit)
+
+			err := DoubleSubmit().Handle(context.Background(), req, resp)
+			assert.NoError(t, err)
+
+			if tt.wantStatus == 0 {
+				assert.False(t, resp.Written())
+				assert.NotEmpty(t, req.CSRFToken())
+			} else {
+				assert.Equal(t, tt.wantStatus, writer.Code)
+			}
+		})
+	}
+}
+
+func TestSynchronizer_Handle(t *testing.T) {
+	var (
+		store      = NewMemoryStore()
+		protection = Synchronizer(store)
+		writer     = httptest.NewRecorder()
+		get        = request.New(httptest.NewRequest(http.MethodGet, "/", nil))
+	)
+
+	err := protection.Handle(context.Background(), get,
+		response.New(writer, types.NewJSONMarshaler(), &types.ResponseAsIs{}),
+	)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, get.CSRFToken())
+
+	var cookies = writer.Result().Cookies()
+	assert.Len(t, cookies, 1)
+
+	for _, token := range []string{get.CSRFToken(), "wrong"} {
+		var r = httptest.NewRequest(http.MethodPost, "/", nil)
+
+		r.AddCookie(cookies[0])
+		r.Header.Set(defaultHeaderName, token)
+
+		writer = httptest.NewRecorder()
+
+		err = protection.Handle(context.Background(), request.New(r),
+			response.New(writer, types.NewJSONMarshaler(), &types.ResponseAsIs{}),
+		)
+		assert.NoError(t, err)
+
+		if token == get.CSRFToken() {
+			assert.Equal(t, http.StatusOK, writer.Code)
+		} else {
+			assert.Equal(t, http.StatusForbidden, writer.Code)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	var (
+		now   = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+		store = NewMemoryStore()
+	)
+
+	store.now = func() time.Time { return now }
+
+	store.Set("active", "a")
+	store.Set("idle", "b")
+
+	now = now.Add(sessionTTL / 2)
+
+	token, ok := store.Get("active")
+	assert.True(t, ok)
+	assert.Equal(t, "a", token)
+
+	// Requests of session prolong its token.
+	now = now.Add(sessionTTL/2 + time.Minute)
+
+	_, ok = store.Get("active")
+	assert.True(t, ok)
+
+	_, ok = store.Get("idle")
+	assert.False(t, ok)
+
+	// Number of sessions is limited.
+	for i := 0; i < maxSessions+10; i++ {
+		store.Set(strconv.Itoa(i), "t")
+	}
+
+	assert.Len(t, store.tokens, maxSessions)
+}
@@ -0,0 +1,104 @@
+package csrf
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// sessionTTL - how long memory store keeps token after last request of session.
+	sessionTTL = 12 * time.Hour
+	// maxSessions - number of sessions memory store keeps, sessions over it evict random ones.
+	maxSessions = 100_000
+	// sweepEvery - expired tokens are removed every 'sweepEvery' saved tokens.
+	sweepEvery = 1024
+)
+
+// Store - keeps synchronizer tokens by session.
+type Store interface {
+	// Get - returns token issued for session.
+	Get(session string) (string, bool)
+	// Set - saves token issued for session.
+	Set(session, token string)
+}
+
+// MemoryStore - in-memory tokens store, suitable for single instance deployments.
+// Token is kept for 12 hours after last request of its session and at most 100000 sessions are kept,
+// so requests without session can't grow store without bound. Client of evicted session gets new token.
+type MemoryStore struct {
+	mutex  sync.Mutex
+	tokens map[string]*session
+	sets   int
+
+	now func() time.Time
+}
+
+type session struct {
+	token   string
+	expires time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tokens: make(map[string]*session),
+		now:    time.Now,
+	}
+}
+
+func (store *MemoryStore) Get(id string) (string, bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var now = store.now()
+
+	stored, ok := store.tokens[id]
+	if !ok || now.After(stored.expires) {
+		return "", false
+	}
+
+	stored.expires = now.Add(sessionTTL)
+
+	return stored.token, true
+}
+
+func (store *MemoryStore) Set(id, token string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var now = store.now()
+
+	store.sets++
+	if store.sets%sweepEvery == 0 {
+		store.sweep(now)
+	}
+
+	if _, ok := store.tokens[id]; !ok && len(store.tokens) >= maxSessions {
+		store.sweep(now)
+		store.evict()
+	}
+
+	store.tokens[id] = &session{
+		token:   token,
+		expires: now.Add(sessionTTL),
+	}
+}
+
+// sweep - removes expired tokens.
+func (store *MemoryStore) sweep(now time.Time) {
+	for id, stored := range store.tokens {
+		if now.After(stored.expires) {
+			delete(store.tokens, id)
+		}
+	}
+}
+
+// evict - removes random sessions until there is place for new one.
+func (store *MemoryStore) evict() {
+	for id := range store.tokens {
+		if len(store.tokens) < maxSessions {
+			return
+		}
+
+		delete(store.tokens, id)
+	}
+}
@@ -0,0 +1,75 @@
+package csrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+)
+
+const tokenLength = 32
+
+func newToken() (string, error) {
+	var token = make([]byte, tokenLength)
+
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// formValue - reads field from url-encoded form body, body is limited by request's max body size.
+// Body is restored after reading, so it still can be extracted by body parameters.
+func formValue(req *request.Request, field string) (string, error) {
+	var r = req.GetRequest()
+
+	if r.Body == nil {
+		return "", nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return "", nil //nolint:nilerr // body without form can't contain token
+	}
+
+	var reader io.Reader = r.Body
+
+	if limit := request.MaxBodySize(req); limit > 0 {
+		reader = io.LimitReader(r.Body, limit+1)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	if limit := request.MaxBodySize(req); limit > 0 && int64(len(body)) > limit {
+		return "", fmt.Errorf("%w: limit is %d bytes", request.ErrBodyTooLarge, limit)
+	}
+
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return "", err
+	}
+
+	return values.Get(field), nil
+}
@@ -18,8 +18,8 @@ func (description) Handle(_ context.Context, _ *request.Request, _ *response.Res
 	return nil
 }
 
-func (description) Docs(*routes.Route) {
-	panic("unimplemented")
+func (desc description) Docs(route *routes.Route) {
+	route.Operation.Description = string(desc)
 }
 
 func (description) Priority() int {
@@ -0,0 +1,137 @@
+package ipfilter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+)
+
+// FromFile - creates filter with rules read from file.
+// File is checked for changes at most once per 'interval' while requests are handled,
+// rules are reloaded if file was modified. Invalid file leaves previous rules in place.
+//
+// File contains rule per line, empty lines and lines starting with '#' are ignored:
+//
+//	# office
+//	allow 192.168.0.0/16
+//	deny  192.168.13.37
+func FromFile(path string, interval time.Duration) (*Filter, error) {
+	var watcher = fileWatcher{
+		path:     path,
+		interval: interval,
+	}
+
+	rules, err := watcher.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var filter = New(*rules)
+
+	filter.reload = watcher.reload
+
+	return filter, nil
+}
+
+// ParseRules - parses rules in file format (see 'FromFile').
+func ParseRules(reader io.Reader) (Rules, error) {
+	var (
+		rules   Rules
+		scanner = bufio.NewScanner(reader)
+	)
+
+	for line := 1; scanner.Scan(); line++ {
+		var fields = strings.Fields(scanner.Text())
+
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if len(fields) != 2 {
+			return Rules{}, fmt.Errorf("line %d: expected '<allow|deny> <network>'", line)
+		}
+
+		prefix, err := request.ParsePrefix(fields[1])
+		if err != nil {
+			return Rules{}, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		switch strings.ToLower(fields[0]) {
+		case "allow":
+			rules.Allow = append(rules.Allow, prefix)
+		case "deny":
+			rules.Deny = append(rules.Deny, prefix)
+		default:
+			return Rules{}, fmt.Errorf("line %d: unknown action '%s'", line, fields[0])
+		}
+	}
+
+	return rules, scanner.Err()
+}
+
+type fileWatcher struct {
+	mutex sync.Mutex
+
+	path     string
+	interval time.Duration
+
+	checked  time.Time
+	modified time.Time
+	size     int64
+}
+
+// reload - returns new rules if file was changed since last check.
+func (watcher *fileWatcher) reload() (*Rules, bool) {
+	if !watcher.mutex.TryLock() {
+		return nil, false // somebody is already checking
+	}
+	defer watcher.mutex.Unlock()
+
+	if time.Since(watcher.checked) < watcher.interval {
+		return nil, false
+	}
+
+	watcher.checked = time.Now()
+
+	info, err := os.Stat(watcher.path)
+	if err != nil || (info.ModTime().Equal(watcher.modified) && info.Size() == watcher.size) {
+		return nil, false
+	}
+
+	rules, err := watcher.read()
+	if err != nil {
+		return nil, false
+	}
+
+	return rules, true
+}
+
+func (watcher *fileWatcher) read() (*Rules, error) {
+	file, err := os.Open(watcher.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := ParseRules(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", watcher.path, err)
+	}
+
+	watcher.checked = time.Now()
+	watcher.modified = info.ModTime()
+	watcher.size = info.Size()
+
+	return &rules, nil
+}
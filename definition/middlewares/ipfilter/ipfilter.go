@@ -0,0 +1,120 @@
+package ipfilter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync/atomic"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+var ErrAddressNotAllowed = errors.New("address is not allowed")
+
+// Rules - lists of allowed and denied networks.
+type Rules struct {
+	Allow []netip.Prefix
+	Deny  []netip.Prefix
+}
+
+// Allows - checks if address allowed by rules.
+// Denied networks take precedence over allowed ones,
+// if there are no allowed networks any address not denied is allowed.
+// Unknown address (e.g. of unix socket client) is never allowed: it can't be checked against denied networks.
+func (rules Rules) Allows(ip netip.Addr) bool {
+	if !ip.IsValid() {
+		return false
+	}
+
+	// IPv4-mapped IPv6 address (e.g. '::ffff:10.0.0.1') is matched with IPv4 networks.
+	ip = ip.Unmap()
+
+	for _, prefix := range rules.Deny {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+
+	if len(rules.Allow) == 0 {
+		return true
+	}
+
+	for _, prefix := range rules.Allow {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter - middleware filtering requests by client IP address (see 'engi.WithTrustedProxies').
+type Filter struct {
+	rules  atomic.Pointer[Rules]
+	reload func() (*Rules, bool)
+}
+
+// Allow - allows requests only from listed networks (CIDRs or single IPs).
+//
+// Panics if address can't be parsed.
+func Allow(cidrs ...string) engi.Middleware {
+	return New(Rules{Allow: mustParse(cidrs)})
+}
+
+// Deny - denies requests from listed networks (CIDRs or single IPs).
+//
+// Panics if address can't be parsed.
+func Deny(cidrs ...string) engi.Middleware {
+	return New(Rules{Deny: mustParse(cidrs)})
+}
+
+// New - creates filter with static rules.
+func New(rules Rules) *Filter {
+	var filter Filter
+
+	filter.rules.Store(&rules)
+
+	return &filter
+}
+
+func (filter *Filter) Handle(_ context.Context, req *request.Request, resp *response.Response) error {
+	if filter.reload != nil {
+		if rules, ok := filter.reload(); ok {
+			filter.rules.Store(rules)
+		}
+	}
+
+	var ip = req.ClientIP()
+
+	if !filter.rules.Load().Allows(ip) {
+		return resp.Forbidden("%s: '%s'", ErrAddressNotAllowed, ip)
+	}
+
+	return nil
+}
+
+// Docs - rules may be reloaded while serving, so they aren't put into static spec.
+func (filter *Filter) Docs(*routes.Route) {}
+
+func (filter *Filter) Priority() int {
+	return 1
+}
+
+func mustParse(cidrs []string) []netip.Prefix {
+	var prefixes = make([]netip.Prefix, len(cidrs))
+
+	for i, cidr := range cidrs {
+		prefix, err := request.ParsePrefix(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("invalid network '%s': %s", cidr, err))
+		}
+
+		prefixes[i] = prefix
+	}
+
+	return prefixes
+}
@@ -0,0 +1,86 @@
+package ipfilter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func handle(t *testing.T, filter engi.Middleware, remote string) int {
+	t.Helper()
+
+	var (
+		r      = httptest.NewRequest(http.MethodGet, "/", nil)
+		writer = httptest.NewRecorder()
+	)
+
+	r.RemoteAddr = remote
+
+	assert.NoError(t, filter.Handle(context.Background(), request.New(r),
+		response.New(writer, types.NewJSONMarshaler(), new(types.ResponseAsIs)),
+	))
+
+	return writer.Code
+}
+
+func TestFilter_Handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     engi.Middleware
+		remote     string
+		wantStatus int
+	}{
+		{"allowed network", Allow("10.0.0.0/8"), "10.1.2.3:80", http.StatusOK},
+		{"not allowed network", Allow("10.0.0.0/8"), "192.168.1.1:80", http.StatusForbidden},
+		{"denied address", Deny("192.168.1.1"), "192.168.1.1:80", http.StatusForbidden},
+		{"not denied address", Deny("192.168.1.1"), "192.168.1.2:80", http.StatusOK},
+		{"unknown address with allow list", Allow("10.0.0.0/8"), "@", http.StatusForbidden},
+		// Address that can't be checked isn't let through deny list.
+		{"unknown address with deny list", Deny("192.168.1.1"), "@", http.StatusForbidden},
+		{"mapped denied address", Deny("192.168.1.0/24"), "[::ffff:192.168.1.1]:80", http.StatusForbidden},
+		{"mapped allowed address", Allow("10.0.0.0/8"), "[::ffff:10.1.2.3]:80", http.StatusOK},
+		{"deny takes precedence", New(Rules{
+			Allow: mustParse([]string{"10.0.0.0/8"}),
+			Deny:  mustParse([]string{"10.0.0.1"}),
+		}), "10.0.0.1:80", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantStatus, handle(t, tt.filter, tt.remote))
+		})
+	}
+}
+
+func TestFromFile(t *testing.T) {
+	var path = filepath.Join(t.TempDir(), "rules")
+
+	assert.NoError(t, os.WriteFile(path, []byte("# admins\nallow 10.0.0.0/8\n"), 0o600))
+
+	filter, err := FromFile(path, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, handle(t, filter, "10.0.0.1:80"))
+
+	assert.NoError(t, os.WriteFile(path, []byte("allow 10.0.0.0/8\ndeny 10.0.0.1\n"), 0o600))
+	assert.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	assert.Equal(t, http.StatusForbidden, handle(t, filter, "10.0.0.1:80"))
+
+	// Invalid file keeps previous rules.
+	assert.NoError(t, os.WriteFile(path, []byte("permit 10.0.0.1\n"), 0o600))
+	assert.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	assert.Equal(t, http.StatusForbidden, handle(t, filter, "10.0.0.1:80"))
+	assert.Equal(t, http.StatusOK, handle(t, filter, "10.0.0.2:80"))
+
+	_, err = FromFile(path, 0)
+	assert.Error(t, err)
+}
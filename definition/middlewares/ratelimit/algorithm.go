@@ -0,0 +1,163 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type (
+	// Algorithm - rate limiting algorithm, consumes requests from state kept in Store.
+	Algorithm interface {
+		// Take - consumes one request from state at moment 'now'.
+		Take(state *State, now time.Time) Result
+		// Limit - returns maximum number of requests.
+		Limit() int
+		// Window - returns period limit is applied to.
+		Window() time.Duration
+	}
+
+	// Result - result of consuming request.
+	Result struct {
+		// Allowed - whether request can be processed.
+		Allowed bool
+		// Remaining - number of requests left.
+		Remaining int
+		// Reset - time left until quota fully restored.
+		Reset time.Duration
+		// RetryAfter - time to wait before next request will be allowed, set only if request denied.
+		RetryAfter time.Duration
+	}
+)
+
+type tokenBucket struct {
+	capacity float64
+	per      time.Duration
+}
+
+// TokenBucket - allows bursts up to 'limit' requests, tokens are refilled evenly during 'per' period.
+func TokenBucket(limit int, per time.Duration) Algorithm {
+	if limit <= 0 || per <= 0 {
+		panic(fmt.Sprintf("invalid token bucket: limit %d per %s", limit, per))
+	}
+
+	return &tokenBucket{
+		capacity: float64(limit),
+		per:      per,
+	}
+}
+
+// Take - uses 'State.Value' as tokens left and 'State.Time' as time of last refill.
+func (bucket *tokenBucket) Take(state *State, now time.Time) Result {
+	var rate = bucket.capacity / float64(bucket.per) // tokens per nanosecond
+
+	if state.Time.IsZero() {
+		state.Value = bucket.capacity
+	} else if elapsed := now.Sub(state.Time); elapsed > 0 {
+		state.Value = math.Min(bucket.capacity, state.Value+float64(elapsed)*rate)
+	}
+
+	state.Time = now
+
+	var result = Result{Allowed: state.Value >= 1}
+
+	if result.Allowed {
+		state.Value--
+	} else {
+		result.RetryAfter = time.Duration((1 - state.Value) / rate)
+	}
+
+	result.Remaining = int(state.Value)
+	result.Reset = time.Duration((bucket.capacity - state.Value) / rate)
+
+	return result
+}
+
+func (bucket *tokenBucket) Limit() int {
+	return int(bucket.capacity)
+}
+
+func (bucket *tokenBucket) Window() time.Duration {
+	return bucket.per
+}
+
+type slidingWindow struct {
+	limit  float64
+	window time.Duration
+}
+
+// SlidingWindow - allows 'limit' requests during any 'window' period.
+// Number of requests is approximated using counters of current and previous windows.
+func SlidingWindow(limit int, window time.Duration) Algorithm {
+	if limit <= 0 || window <= 0 {
+		panic(fmt.Sprintf("invalid sliding window: limit %d per %s", limit, window))
+	}
+
+	return &slidingWindow{
+		limit:  float64(limit),
+		window: window,
+	}
+}
+
+// Take - uses 'State.Value' and 'State.Previous' as counters of current and previous windows
+// and 'State.Time' as start of current window.
+func (sliding *slidingWindow) Take(state *State, now time.Time) Result {
+	var start = now.Truncate(sliding.window)
+
+	if !state.Time.Equal(start) {
+		if state.Time.Equal(start.Add(-sliding.window)) {
+			state.Previous = state.Value
+		} else {
+			state.Previous = 0
+		}
+
+		state.Value = 0
+		state.Time = start
+	}
+
+	var (
+		elapsed = now.Sub(start)
+		weight  = 1 - float64(elapsed)/float64(sliding.window)
+		count   = state.Previous*weight + state.Value
+		result  = Result{
+			Allowed: count+1 <= sliding.limit,
+			Reset:   sliding.window - elapsed,
+		}
+	)
+
+	if result.Allowed {
+		state.Value++
+		count++
+	} else {
+		result.RetryAfter = sliding.retryAfter(state, elapsed)
+	}
+
+	result.Remaining = int(math.Max(0, sliding.limit-math.Ceil(count)))
+
+	return result
+}
+
+// retryAfter - calculates time until weighted count of requests drops enough to allow one more.
+func (sliding *slidingWindow) retryAfter(state *State, elapsed time.Duration) time.Duration {
+	var window = float64(sliding.window)
+
+	// Previous window weight decreases during current window.
+	if state.Previous > 0 {
+		if at := window * (1 - (sliding.limit-1-state.Value)/state.Previous); at <= window {
+			return max(0, time.Duration(at)-elapsed)
+		}
+	}
+
+	// Current window becomes previous one.
+	var at = window * (1 - (sliding.limit-1)/state.Value)
+
+	return sliding.window - elapsed + time.Duration(math.Max(0, at))
+}
+
+func (sliding *slidingWindow) Limit() int {
+	return int(sliding.limit)
+}
+
+func (sliding *slidingWindow) Window() time.Duration {
+	return sliding.window
+}
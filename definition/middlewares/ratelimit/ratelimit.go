@@ -0,0 +1,166 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/internal/docs"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+const (
+	limitHeader      = "RateLimit-Limit"
+	remainingHeader  = "RateLimit-Remaining"
+	resetHeader      = "RateLimit-Reset"
+	policyHeader     = "RateLimit-Policy"
+	retryAfterHeader = "Retry-After"
+)
+
+var ErrTooManyRequests = errors.New("too many requests")
+
+type (
+	// Option - configures rate limiting middleware.
+	Option func(*Limiter)
+
+	// Limiter - rate limiting middleware.
+	Limiter struct {
+		name      string
+		key       func(*request.Request) string
+		algorithm Algorithm
+		store     Store
+		now       func() time.Time
+	}
+)
+
+// PerIP - limits requests by client IP address (see 'engi.WithTrustedProxies').
+// Requests without known address (e.g. came through unix socket) aren't limited.
+func PerIP(algorithm Algorithm, options ...Option) engi.Middleware {
+	return newLimiter("ip", func(r *request.Request) string {
+		if ip := r.ClientIP(); ip.IsValid() {
+			return ip.String()
+		}
+
+		return ""
+	}, algorithm, options...)
+}
+
+// PerKey - limits requests by key returned from function.
+// Requests with empty key aren't limited.
+func PerKey(key func(engi.Request) string, algorithm Algorithm, options ...Option) engi.Middleware {
+	return newLimiter("key", func(r *request.Request) string {
+		return key(r)
+	}, algorithm, options...)
+}
+
+// PerPrincipal - limits requests by principal set by 'auth' middleware.
+// Requests without principal aren't limited.
+func PerPrincipal(algorithm Algorithm, options ...Option) engi.Middleware {
+	return newLimiter("principal", func(r *request.Request) string {
+		return r.Principal()
+	}, algorithm, options...)
+}
+
+// WithStore - sets store for algorithm state, by default in-memory store used.
+// Limiters sharing store should have different names (see 'WithName').
+func WithStore(store Store) Option {
+	return func(limiter *Limiter) {
+		limiter.store = store
+	}
+}
+
+// WithName - sets name used as prefix of keys in store.
+// Limiters with same name and store share their limits.
+func WithName(name string) Option {
+	return func(limiter *Limiter) {
+		limiter.name = name
+	}
+}
+
+func newLimiter(
+	name string,
+	key func(*request.Request) string,
+	algorithm Algorithm,
+	options ...Option,
+) *Limiter {
+	var limiter = Limiter{
+		name:      name,
+		key:       key,
+		algorithm: algorithm,
+		now:       time.Now,
+	}
+
+	for _, option := range options {
+		option(&limiter)
+	}
+
+	if limiter.store == nil {
+		limiter.store = NewMemoryStore()
+	}
+
+	return &limiter
+}
+
+func (limiter *Limiter) Handle(ctx context.Context, req *request.Request, resp *response.Response) error {
+	var key = limiter.key(req)
+	if key == "" {
+		return nil
+	}
+
+	var result Result
+
+	if err := limiter.store.Update(ctx,
+		fmt.Sprintf("%s:%s", limiter.name, key),
+		2*limiter.algorithm.Window(), // sliding window needs previous window's state
+		func(state *State) {
+			result = limiter.algorithm.Take(state, limiter.now())
+		},
+	); err != nil {
+		return resp.InternalServerError(err.Error())
+	}
+
+	var header = resp.ResponseWriter().Header()
+
+	header.Set(limitHeader, strconv.Itoa(limiter.algorithm.Limit()))
+	header.Set(remainingHeader, strconv.Itoa(result.Remaining))
+	header.Set(resetHeader, seconds(result.Reset))
+	header.Set(policyHeader, fmt.Sprintf("%d;w=%s",
+		limiter.algorithm.Limit(), seconds(limiter.algorithm.Window()),
+	))
+
+	if !result.Allowed {
+		header.Set(retryAfterHeader, seconds(result.RetryAfter))
+
+		return resp.TooManyRequests(ErrTooManyRequests.Error())
+	}
+
+	return nil
+}
+
+func (limiter *Limiter) Docs(route *routes.Route) {
+	route.Operation.Responses[strconv.Itoa(http.StatusTooManyRequests)] = &docs.Response{
+		Description: http.StatusText(http.StatusTooManyRequests),
+		Headers: map[string]*docs.Header{
+			retryAfterHeader: {
+				Description: "seconds to wait before next request",
+				Schema:      &docs.Schema{Type: "integer"},
+			},
+		},
+	}
+}
+
+func (limiter *Limiter) Priority() int {
+	return 30
+}
+
+// seconds - formats duration as number of seconds rounded up.
+func seconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore - imitates remote store keeping serialized states.
+type fakeStore struct {
+	mutex  sync.Mutex
+	states map[string][]byte
+}
+
+func (store *fakeStore) Update(_ context.Context, key string, _ time.Duration, update func(*State)) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var state State
+
+	if raw, ok := store.states[key]; ok {
+		if err := json.Unmarshal(raw, &state); err != nil {
+			return err
+		}
+	}
+
+	update(&state)
+
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	store.states[key] = raw
+
+	return nil
+}
+
+func TestTokenBucket_Take(t *testing.T) {
+	var (
+		bucket = TokenBucket(2, time.Second)
+		state  State
+		now    = time.Unix(100, 0)
+	)
+
+	assert.True(t, bucket.Take(&state, now).Allowed)
+	assert.True(t, bucket.Take(&state, now).Allowed)
+
+	var result = bucket.Take(&state, now)
+	assert.False(t, result.Allowed)
+	assert.InDelta(t, 500*time.Millisecond, result.RetryAfter, float64(time.Millisecond))
+
+	assert.True(t, bucket.Take(&state, now.Add(500*time.Millisecond)).Allowed)
+}
+
+func TestSlidingWindow_Take(t *testing.T) {
+	var (
+		window = SlidingWindow(2, time.Second)
+		state  State
+		now    = time.Unix(100, 0)
+	)
+
+	assert.True(t, window.Take(&state, now).Allowed)
+	assert.True(t, window.Take(&state, now.Add(100*time.Millisecond)).Allowed)
+
+	var result = window.Take(&state, now.Add(200*time.Millisecond))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// Half of previous window requests still counted.
+	assert.True(t, window.Take(&state, now.Add(1500*time.Millisecond)).Allowed)
+	assert.False(t, window.Take(&state, now.Add(1600*time.Millisecond)).Allowed)
+
+	assert.True(t, window.Take(&state, now.Add(3*time.Second)).Allowed)
+}
+
+func TestLimiter_Handle(t *testing.T) {
+	var (
+		store   = &fakeStore{states: make(map[string][]byte)}
+		limiter = PerKey(func(r engi.Request) string {
+			return r.GetRequest().Header.Get("X-Key")
+		}, TokenBucket(1, time.Minute), WithStore(store))
+	)
+
+	for _, tt := range []struct {
+		key        string
+		wantStatus int
+		wantHeader map[string]string
+	}{
+		{key: "", wantStatus: http.StatusOK},
+		{key: "a", wantStatus: http.StatusOK, wantHeader: map[string]string{
+			limitHeader:     "1",
+			remainingHeader: "0",
+			resetHeader:     "60",
+			policyHeader:    "1;w=60",
+		}},
+		{key: "a", wantStatus: http.StatusTooManyRequests, wantHeader: map[string]string{
+			remainingHeader:  "0",
+			retryAfterHeader: "60",
+		}},
+		{key: "b", wantStatus: http.StatusOK},
+	} {
+		var (
+			r      = httptest.NewRequest(http.MethodGet, "/", nil)
+			writer = httptest.NewRecorder()
+		)
+
+		r.Header.Set("X-Key", tt.key)
+
+		assert.NoError(t, limiter.Handle(context.Background(), request.New(r),
+			response.New(writer, types.NewJSONMarshaler(), new(types.ResponseAsIs)),
+		))
+		assert.Equal(t, tt.wantStatus, writer.Code, tt.key)
+
+		for key, value := range tt.wantHeader {
+			assert.Equal(t, value, writer.Header().Get(key), key)
+		}
+	}
+}
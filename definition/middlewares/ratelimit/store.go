@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultShards = 32
+	sweepEvery    = 1024
+)
+
+type (
+	// State - algorithm state kept in Store by key.
+	State struct {
+		Value    float64
+		Previous float64
+		Time     time.Time
+	}
+
+	// Store - keeps algorithms state.
+	// Implementations for distributed storages (e.g. Redis) should apply update atomically,
+	// for example using optimistic transactions.
+	Store interface {
+		// Update - atomically applies 'update' to state stored by key.
+		// New state must be stored at least for 'ttl', missing state passed as zero value.
+		Update(ctx context.Context, key string, ttl time.Duration, update func(*State)) error
+	}
+)
+
+// MemoryStore - in-memory sharded store, suitable for single instance deployments.
+type MemoryStore struct {
+	shards []*shard
+}
+
+type shard struct {
+	mutex   sync.Mutex
+	states  map[string]*entry
+	updates int
+}
+
+type entry struct {
+	state   State
+	expires time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	var store = MemoryStore{
+		shards: make([]*shard, defaultShards),
+	}
+
+	for i := range store.shards {
+		store.shards[i] = &shard{
+			states: make(map[string]*entry),
+		}
+	}
+
+	return &store
+}
+
+func (store *MemoryStore) Update(_ context.Context, key string, ttl time.Duration, update func(*State)) error {
+	var (
+		now   = time.Now()
+		shard = store.shard(key)
+	)
+
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+
+	shard.updates++
+	if shard.updates%sweepEvery == 0 {
+		shard.sweep(now)
+	}
+
+	stored, ok := shard.states[key]
+	if !ok || now.After(stored.expires) {
+		stored = new(entry)
+		shard.states[key] = stored
+	}
+
+	update(&stored.state)
+	stored.expires = now.Add(ttl)
+
+	return nil
+}
+
+func (store *MemoryStore) shard(key string) *shard {
+	var hash = fnv.New32a()
+
+	_, _ = hash.Write([]byte(key))
+
+	return store.shards[hash.Sum32()%uint32(len(store.shards))]
+}
+
+// sweep - removes expired states.
+func (shard *shard) sweep(now time.Time) {
+	for key, stored := range shard.states {
+		if now.After(stored.expires) {
+			delete(shard.states, key)
+		}
+	}
+}
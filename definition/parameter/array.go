@@ -0,0 +1,298 @@
+package parameter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/types"
+)
+
+// ArrayStyle - defines how multiple values of parameter are sent (OpenAPI 'style' and 'explode').
+type ArrayStyle string
+
+const (
+	// Repeat - each value sent separately: '?id=1&id=2' (style 'form', explode).
+	Repeat ArrayStyle = "repeat"
+	// Comma - values separated by comma: '?id=1,2' (style 'form' or 'simple' for headers).
+	Comma ArrayStyle = "comma"
+	// Pipe - values separated by pipe: '?id=1|2' (style 'pipeDelimited').
+	Pipe ArrayStyle = "pipe"
+	// Space - values separated by space: '?id=1%202' (style 'spaceDelimited').
+	Space ArrayStyle = "space"
+)
+
+type array struct {
+	style    ArrayStyle
+	minItems *int
+	maxItems *int
+
+	// collect - converts parsed values to typed slice.
+	collect func([]any) any
+}
+
+// Style - sets how multiple values of parameter are sent.
+// By default values are repeated in query and separated by comma in headers and cookies.
+func Style(style ArrayStyle) request.Option {
+	return arraySetting(func(array *array) {
+		array.style = style
+	})
+}
+
+// MinItems - sets minimal number of values of multi-value parameter.
+func MinItems(n int) request.Option {
+	return arraySetting(func(array *array) {
+		array.minItems = &n
+	})
+}
+
+// MaxItems - sets maximal number of values of multi-value parameter.
+func MaxItems(n int) request.Option {
+	return arraySetting(func(array *array) {
+		array.maxItems = &n
+	})
+}
+
+func arraySetting(set func(*array)) setting {
+	return func(parameter *Parameter) {
+		if parameter.array == nil {
+			panic(fmt.Sprintf("parameter '%s' isn't multi-value parameter", parameter.key))
+		}
+
+		set(parameter.array)
+	}
+}
+
+// newArray - creates multi-value parameter, validators are applied to each value.
+func newArray[T any](
+	key string,
+	place placing.Placing,
+	typeName, regexp string,
+	parse func(string) (T, error),
+	options []request.Option,
+) *Parameter {
+	var parameter = Parameter{
+		key:       key,
+		placing:   place,
+		typeName:  typeName,
+		regexp:    regexp,
+		valueType: reflect.TypeFor[T](),
+		parse: func(value string) (any, error) {
+			return parse(value)
+		},
+		array: &array{
+			style: Repeat,
+			collect: func(values []any) any {
+				var result = make([]T, len(values))
+
+				for i, value := range values {
+					result[i] = value.(T)
+				}
+
+				return result
+			},
+		},
+	}
+
+	if place == placing.InHeader || place == placing.InCookie {
+		parameter.array.style = Comma
+	}
+
+	parameter.configure(options)
+
+	if parameter.defaultValue != nil {
+		if _, ok := parameter.defaultValue.([]T); !ok {
+			panic(fmt.Sprintf("invalid default value of parameter '%s': must be of type %T",
+				key, []T{},
+			))
+		}
+	}
+
+	return &parameter
+}
+
+func (parameter Parameter) extractArray(r *request.Request) error {
+	var (
+		location = string(parameter.placing)
+		values   = parameter.array.style.split(
+			request.Values(r, parameter.key, parameter.placing),
+		)
+	)
+
+	if len(values) == 0 {
+		if !parameter.optional {
+			return request.Failures(fmt.Errorf("parameter not found: %s", parameter.key),
+				location, parameter.key, "required",
+			)
+		}
+
+		request.SetParsed(r, parameter.key, parameter.placing, parameter.defaultValue)
+
+		return nil
+	}
+
+	if min := parameter.array.minItems; min != nil && len(values) < *min {
+		return request.Failures(fmt.Errorf("parameter '%s' should have at least %d items", parameter.key, *min),
+			location, parameter.key, "min_items",
+		)
+	}
+
+	if max := parameter.array.maxItems; max != nil && len(values) > *max {
+		return request.Failures(fmt.Errorf("parameter '%s' should have at most %d items", parameter.key, *max),
+			location, parameter.key, "max_items",
+		)
+	}
+
+	var (
+		parsed   = make([]any, len(values))
+		failures types.ValidationErrors
+	)
+
+	for i, value := range values {
+		var item = request.Parameter{
+			Name: fmt.Sprintf("%s[%d]", parameter.key, i),
+		}
+
+		result, err := parameter.parse(value)
+		if err != nil {
+			failures = append(failures, request.Failures(
+				fmt.Errorf("can't convert parameter '%s': %s", item.Name, err),
+				location, item.Name, "type",
+			)...)
+
+			continue
+		}
+
+		item.Parsed = result
+
+		failures = append(failures, request.Validate(&item, location, parameter.options)...)
+
+		parsed[i] = item.Parsed
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+
+	request.SetParsed(r, parameter.key, parameter.placing, parameter.array.collect(parsed))
+
+	return nil
+}
+
+// split - splits sent values by style's separator, empty values are dropped.
+func (style ArrayStyle) split(values []string) []string {
+	var separator string
+
+	switch style {
+	case Comma:
+		separator = ","
+	case Pipe:
+		separator = "|"
+	case Space:
+		separator = " "
+	}
+
+	var result = make([]string, 0, len(values))
+
+	for _, value := range values {
+		// Empty value like '?id=' means that parameter wasn't sent.
+		if separator == "" {
+			if value != "" {
+				result = append(result, value)
+			}
+
+			continue
+		}
+
+		for _, item := range strings.Split(value, separator) {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+
+	return result
+}
+
+// docs - returns OpenAPI 'style' and 'explode' of parameter.
+func (style ArrayStyle) docs(place placing.Placing) (string, *bool) {
+	var explode = false
+
+	switch {
+	case place == placing.InHeader:
+		return "simple", &explode
+	case style == Pipe:
+		return "pipeDelimited", &explode
+	case style == Space:
+		return "spaceDelimited", &explode
+	case style == Comma:
+		return "form", &explode
+	default:
+		explode = true
+
+		return "form", &explode
+	}
+}
+
+// Bools - mandatory multi-value boolean Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Bools'.
+func Bools(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newArray(key, place, "bool", `(1|t|T|TRUE|true|True|0|f|F|FALSE|false|False)`,
+		strconv.ParseBool,
+		options,
+	)
+}
+
+// Integers - mandatory multi-value integer Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Integers'.
+func Integers(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newArray(key, place, "int64", `((\+|-)?\d+)`,
+		func(p string) (int64, error) {
+			return strconv.ParseInt(p, request.IntBase, request.BitSize)
+		},
+		options,
+	)
+}
+
+// Floats - mandatory multi-value floating point number Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Floats'.
+func Floats(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newArray(key, place, "float64", `((\+|-)?\d+(\.\d+)?)`,
+		func(p string) (float64, error) {
+			return strconv.ParseFloat(p, request.BitSize)
+		},
+		options,
+	)
+}
+
+// Strings - mandatory multi-value string Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Strings'.
+func Strings(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newArray(key, place, "string", `(.+)`,
+		func(p string) (string, error) {
+			return p, nil
+		},
+		options,
+	)
+}
+
+// Times - mandatory multi-value time Parameter from request by 'key' using 'layout'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Times'.
+func Times(key, layout string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newArray(key, place, "time", numRegexp.ReplaceAllString(layout, `\d`),
+		func(p string) (time.Time, error) {
+			return time.Parse(layout, p)
+		},
+		options,
+	)
+}
@@ -2,8 +2,15 @@ package parameter
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
 
 	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/response"
 	"github.com/kliuchnikovv/engi/internal/routes"
@@ -17,11 +24,14 @@ type BodyParameter struct {
 	options     []request.Option
 }
 
-// func (body *BodyParameter) Bind(route *routes.Route) error {
-// 	// route.Body = body
+// Bind - checks that validators can validate body.
+func (body *BodyParameter) Bind(*routes.Route) error {
+	if err := request.Check(reflect.TypeOf(body.pointer), body.options); err != nil {
+		return fmt.Errorf("body: %w", err)
+	}
 
-// 	return nil
-// }
+	return nil
+}
 
 func (body *BodyParameter) Handle(ctx context.Context, r *request.Request, response *response.Response) error {
 	var (
@@ -32,15 +42,45 @@ func (body *BodyParameter) Handle(ctx context.Context, r *request.Request, respo
 	if unmarshaler == nil {
 		unmarshaler, err = request.GetUnmarshaler(r)
 		if err != nil {
-			return response.InternalServerError(err.Error())
+			return response.UnsupportedMediaType(err.Error())
 		}
 	}
 
-	return request.ExtractBody(r, unmarshaler, body.pointer, body.options)
+	if err := request.ExtractBody(r, unmarshaler, body.pointer, body.options); err != nil {
+		return bodyError(response, err)
+	}
+
+	return nil
 }
 
+// Docs - describes body for every media type of route's codecs able to decode it.
 func (body *BodyParameter) Docs(route *routes.Route) {
-	panic("not implemented")
+	var (
+		schema       = docs.SchemaOf(reflect.TypeOf(body.pointer))
+		contentTypes = []string{"application/json"}
+	)
+
+	if body.unmarshaler == nil {
+		var codecs = route.Codecs
+		if codecs == nil {
+			codecs = codec.Default()
+		}
+
+		contentTypes = codecs.DecodingTypes(reflect.TypeOf(body.pointer))
+
+		route.Operation.Responses[strconv.Itoa(http.StatusUnsupportedMediaType)] = &docs.Response{
+			Description: http.StatusText(http.StatusUnsupportedMediaType),
+		}
+	}
+
+	route.Operation.RequestBody = &docs.RequestBody{
+		Required: true,
+		Content:  make(map[string]*docs.MediaType, len(contentTypes)),
+	}
+
+	for _, contentType := range contentTypes {
+		route.Operation.RequestBody.Content[contentType] = &docs.MediaType{Schema: schema}
+	}
 }
 
 func (body *BodyParameter) Priority() int {
@@ -48,16 +88,18 @@ func (body *BodyParameter) Priority() int {
 }
 
 // Body - takes pointer to structure and saves casted request body into context and pointer.
+// Fields of structure are validated using 'validate' tags (see 'validate.Struct').
 //
 // Result can be retrieved from context using 'context.QueryParams.Body'.
 func Body(pointer interface{}, options ...request.Option) engi.Middleware {
 	return &BodyParameter{
 		pointer: pointer,
-		options: options,
+		options: append([]request.Option{validate.Struct}, options...),
 	}
 }
 
 // CustomBody - takes unmarshaler and pointer to structure and saves casted request body into context.
+// Fields of structure are validated using 'validate' tags (see 'validate.Struct').
 //
 // Result can be retrieved from context using 'context.QueryParams.Body'.
 func CustomBody(
@@ -68,6 +110,35 @@ func CustomBody(
 	return &BodyParameter{
 		pointer:     pointer,
 		unmarshaler: unmarshaler,
-		options:     options,
+		options:     append([]request.Option{validate.Struct}, options...),
 	}
 }
+
+type maxBodySize int64
+
+// MaxBodySize - limits size of route's request body in bytes, overrides limit set by 'engi.WithMaxBodySize'.
+// Bodies exceeding limit are rejected with 413 code.
+//
+// Panics if size isn't positive.
+func MaxBodySize(size int64) engi.Middleware {
+	if size <= 0 {
+		panic(fmt.Sprintf("invalid body size: %d", size))
+	}
+
+	return maxBodySize(size)
+}
+
+func (size maxBodySize) Handle(_ context.Context, r *request.Request, _ *response.Response) error {
+	request.SetMaxBodySize(r, int64(size))
+
+	return nil
+}
+
+func (size maxBodySize) Docs(route *routes.Route) {
+	route.Operation.LimitBody(int64(size))
+}
+
+// Priority - should be set before body is read by any middleware (e.g. 'csrf' reading form's token).
+func (maxBodySize) Priority() int {
+	return 0
+}
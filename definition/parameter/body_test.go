@@ -0,0 +1,178 @@
+package parameter_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/stretchr/testify/assert"
+)
+
+type note struct {
+	Text string `json:"text"`
+}
+
+type noteService struct{}
+
+func (s *noteService) Prefix() string {
+	return "notes"
+}
+
+func (s *noteService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.PST("short"): engi.Handle(s.create,
+			parameter.Body(new(note)),
+		),
+		engi.PST("long"): engi.Handle(s.create,
+			parameter.MaxBodySize(64),
+			parameter.Body(new(note)),
+		),
+	}
+}
+
+func (s *noteService) create(_ context.Context, req engi.Request, resp engi.Response) error {
+	return resp.OK(req.Body())
+}
+
+// reader - hides length of body, so it's sent chunked.
+type reader struct {
+	io.Reader
+}
+
+func TestMaxBodySize(t *testing.T) {
+	var engine = engi.New("", engi.WithMaxBodySize(32))
+
+	assert.NoError(t, engine.RegisterServices(&noteService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	var long = `{"text":"` + strings.Repeat("a", 40) + `"}`
+
+	tests := []struct {
+		name       string
+		path       string
+		body       io.Reader
+		wantStatus int
+		wantBody   string
+	}{
+		{"short", "short", strings.NewReader(`{"text":"a"}`), http.StatusOK, `{"text":"a"}`},
+		{"too large", "short", strings.NewReader(long), http.StatusRequestEntityTooLarge,
+			`"request body too large: limit is 32 bytes"`},
+		{"too large chunked", "short", reader{strings.NewReader(long)}, http.StatusRequestEntityTooLarge,
+			`"request body too large: limit is 32 bytes"`},
+		{"route limit", "long", reader{strings.NewReader(long)}, http.StatusOK,
+			`{"text":"` + strings.Repeat("a", 40) + `"}`},
+		{"empty", "short", strings.NewReader(""), http.StatusBadRequest,
+			`[{"location":"body","rule":"required","message":"no body found after reading"}]`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Post(server.URL+"/notes/"+tt.path, "application/json", tt.body)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+		assert.Equal(t, tt.wantBody, string(body), tt.name)
+	}
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			MaxBodySize int64                      `json:"x-max-body-size"`
+			Responses   map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	for path, size := range map[string]int64{"/notes/short": 32, "/notes/long": 64} {
+		var operation = document.Paths[path]["post"]
+
+		assert.Equal(t, size, operation.MaxBodySize, path)
+		assert.Contains(t, operation.Responses, "413", path)
+	}
+
+	assert.Panics(t, func() { parameter.MaxBodySize(0) })
+}
+
+func TestBodyContentType(t *testing.T) {
+	var (
+		form = codec.New("application/x-www-form-urlencoded", nil, func(data []byte, pointer interface{}) error {
+			values, err := url.ParseQuery(string(data))
+			if err != nil {
+				return err
+			}
+
+			pointer.(*note).Text = values.Get("text")
+
+			return nil
+		})
+		engine = engi.New("", engi.WithCodecs(form))
+	)
+
+	assert.NoError(t, engine.RegisterServices(&noteService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		contentType string
+		body        string
+		wantStatus  int
+		wantBody    string
+	}{
+		{"application/json; charset=utf-8", `{"text":"a"}`, http.StatusOK, `{"text":"a"}`},
+		{"application/merge-patch+json", `{"text":"b"}`, http.StatusOK, `{"text":"b"}`},
+		{"application/xml", `<note><Text>c</Text></note>`, http.StatusOK, `{"text":"c"}`},
+		{"application/x-www-form-urlencoded", `text=d`, http.StatusOK, `{"text":"d"}`},
+		{"text/csv", `text`, http.StatusUnsupportedMediaType,
+			`"unsupported media type: 'text/csv', supported: application/json, application/xml, text/plain, application/x-www-form-urlencoded"`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Post(server.URL+"/notes/long", tt.contentType, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.contentType)
+		assert.Equal(t, tt.wantBody, string(body), tt.contentType)
+	}
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]json.RawMessage `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	var operation = document.Paths["/notes/long"]["post"]
+	// Text codec can't decode structure.
+	assert.Len(t, operation.RequestBody.Content, 3)
+	assert.Contains(t, operation.RequestBody.Content, "application/x-www-form-urlencoded")
+	assert.NotContains(t, operation.RequestBody.Content, "text/plain")
+	assert.Contains(t, operation.Responses, "415")
+}
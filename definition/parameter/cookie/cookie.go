@@ -0,0 +1,167 @@
+package cookie
+
+import (
+	"encoding"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/request"
+)
+
+// Bool - mandatory boolean Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Bool'.
+func Bool(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Bool(key, placing.InCookie, opts...)
+}
+
+// Integer - queries mandatory integer Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Integer'.
+func Integer(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Integer(key, placing.InCookie, opts...)
+}
+
+// Float - mandatory floating point number Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Float'.
+func Float(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Float(key, placing.InCookie, opts...)
+}
+
+// String - mandatory string Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.String'.
+func String(key string, opts ...request.Option) engi.Middleware {
+	return parameter.String(key, placing.InCookie, opts...)
+}
+
+// Time - mandatory time Parameter from request by 'key' using 'layout'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Time'.
+func Time(key, layout string, opts ...request.Option) engi.Middleware {
+	return parameter.Time(key, layout, placing.InCookie, opts...)
+}
+
+// Bools - mandatory multi-value boolean Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Bools'.
+func Bools(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Bools(key, placing.InCookie, opts...)
+}
+
+// Integers - mandatory multi-value integer Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Integers'.
+func Integers(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Integers(key, placing.InCookie, opts...)
+}
+
+// Floats - mandatory multi-value floating point number Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Floats'.
+func Floats(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Floats(key, placing.InCookie, opts...)
+}
+
+// Strings - mandatory multi-value string Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Strings'.
+func Strings(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Strings(key, placing.InCookie, opts...)
+}
+
+// Times - mandatory multi-value time Parameter from request by 'key' using 'layout'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Times'.
+func Times(key, layout string, opts ...request.Option) engi.Middleware {
+	return parameter.Times(key, layout, placing.InCookie, opts...)
+}
+
+// Uint - mandatory unsigned integer Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Uint'.
+func Uint(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Uint(key, placing.InCookie, opts...)
+}
+
+// Duration - mandatory duration Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Duration'.
+func Duration(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Duration(key, placing.InCookie, opts...)
+}
+
+// UUID - mandatory UUID Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.UUID'.
+func UUID(key string, opts ...request.Option) engi.Middleware {
+	return parameter.UUID(key, placing.InCookie, opts...)
+}
+
+// Enum - mandatory string Parameter from request by 'key' which must be one of 'values'.
+//
+// Result can be retrieved from context using 'context.QueryParams.String'.
+func Enum(key string, values []string, opts ...request.Option) engi.Middleware {
+	return parameter.Enum(key, values, placing.InCookie, opts...)
+}
+
+// Decimal - mandatory arbitrary precision decimal Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Decimal'.
+func Decimal(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Decimal(key, placing.InCookie, opts...)
+}
+
+// IP - mandatory IP address Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.IP'.
+func IP(key string, opts ...request.Option) engi.Middleware {
+	return parameter.IP(key, placing.InCookie, opts...)
+}
+
+// CIDR - mandatory network Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.CIDR'.
+func CIDR(key string, opts ...request.Option) engi.Middleware {
+	return parameter.CIDR(key, placing.InCookie, opts...)
+}
+
+// Email - mandatory email address Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.String'.
+func Email(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Email(key, placing.InCookie, opts...)
+}
+
+// URL - mandatory absolute URL Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.URL'.
+func URL(key string, opts ...request.Option) engi.Middleware {
+	return parameter.URL(key, placing.InCookie, opts...)
+}
+
+// Bytes - mandatory base64 encoded Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Bytes'.
+func Bytes(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Bytes(key, placing.InCookie, opts...)
+}
+
+// Custom - mandatory Parameter of any type from request by 'key' converted using 'parse'.
+//
+// Result can be retrieved from context using 'engi.Param[T]'.
+func Custom[T any](key string, parse func(string) (T, error), opts ...request.Option) engi.Middleware {
+	return parameter.Custom(key, placing.InCookie, parse, opts...)
+}
+
+// Text - mandatory Parameter from request by 'key' converted using 'UnmarshalText' method of type 'T'.
+//
+// Result can be retrieved from context using 'engi.Param[T]'.
+func Text[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](key string, opts ...request.Option) engi.Middleware {
+	return parameter.Text[T, P](key, placing.InCookie, opts...)
+}
@@ -0,0 +1,52 @@
+package parameter
+
+import (
+	"encoding"
+	"reflect"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/docs"
+	"github.com/kliuchnikovv/engi/internal/request"
+)
+
+// Custom - mandatory Parameter of any type from request by 'key' converted using 'parse'.
+// Validators receive converted value, documentation schema is derived from type 'T'.
+//
+// Result can be retrieved from context using 'engi.Param[T]'.
+func Custom[T any](
+	key string,
+	place placing.Placing,
+	parse func(string) (T, error),
+	options ...request.Option,
+) engi.Middleware {
+	var parameter = newParameter(key, place, reflect.TypeFor[T]().String(), `(.+)`, parse, options)
+
+	parameter.custom = docs.SchemaOf(reflect.TypeFor[T]())
+
+	return parameter
+}
+
+// Text - mandatory Parameter from request by 'key' converted using 'UnmarshalText' method of type 'T'.
+//
+//	parameter.Text[netip.Addr]("ip", placing.InQuery)
+//
+// Result can be retrieved from context using 'engi.Param[T]'.
+func Text[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](
+	key string,
+	place placing.Placing,
+	options ...request.Option,
+) engi.Middleware {
+	return Custom(key, place, func(value string) (T, error) {
+		var result T
+
+		if err := P(&result).UnmarshalText([]byte(value)); err != nil {
+			return result, err
+		}
+
+		return result, nil
+	}, options...)
+}
@@ -0,0 +1,114 @@
+package parameter_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/definition/parameter/query"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type point struct {
+	X, Y int
+}
+
+func parsePoint(value string) (point, error) {
+	var p point
+
+	if _, err := fmt.Sscanf(strings.ReplaceAll(value, ",", " "), "%d %d", &p.X, &p.Y); err != nil {
+		return p, fmt.Errorf("invalid point '%s'", value)
+	}
+
+	return p, nil
+}
+
+type customService struct{}
+
+func (s *customService) Prefix() string {
+	return "custom"
+}
+
+func (s *customService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET(""): engi.Handle(s.get,
+			query.Custom("at", parsePoint, validate.NotEmpty),
+			query.Text[netip.Addr]("ip"),
+		),
+	}
+}
+
+func (s *customService) get(_ context.Context, req engi.Request, resp engi.Response) error {
+	at, ok := engi.Param[point](req, "at", placing.InQuery)
+	if !ok {
+		return resp.InternalServerError("point not found")
+	}
+
+	ip, _ := engi.Param[netip.Addr](req, "ip", placing.InQuery)
+	_, mismatch := engi.Param[string](req, "ip", placing.InQuery)
+
+	return resp.OK(map[string]any{
+		"x":        at.X,
+		"y":        at.Y,
+		"ip":       ip,
+		"mismatch": mismatch,
+	})
+}
+
+func TestCustomParameters(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&customService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{"?at=1,2&ip=10.0.0.1", http.StatusOK, `{"ip":"10.0.0.1","mismatch":false,"x":1,"y":2}`},
+		{"?at=1&ip=10.0.0.1", http.StatusBadRequest, `[{"location":"query","field":"at","rule":"type","message":"can't convert parameter 'at': invalid point '1'"}]`},
+		{"?at=1,2&ip=localhost", http.StatusBadRequest, `[{"location":"query","field":"ip","rule":"type","message":"can't convert parameter 'ip': ParseAddr(\"localhost\"): unable to parse IP"}]`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(server.URL + "/custom/" + tt.query)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.query)
+		assert.Equal(t, tt.wantBody, string(body), tt.query)
+	}
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Schema map[string]any `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	var parameters = document.Paths["/custom/"]["get"].Parameters
+	assert.Len(t, parameters, 2)
+	assert.Equal(t, "object", parameters[0].Schema["type"])
+	assert.Equal(t, "string", parameters[1].Schema["type"])
+}
@@ -0,0 +1,103 @@
+package parameter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/internal/docs"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+type FileParameter struct {
+	key    string
+	upload request.Upload
+}
+
+// Bind - registers file, so it's streamed to its destination when form is read.
+func (file *FileParameter) Bind(route *routes.Route) error {
+	if route.Uploads == nil {
+		route.Uploads = make(map[string]request.Upload)
+	}
+
+	if _, ok := route.Uploads[file.key]; ok {
+		return fmt.Errorf("file '%s' is already defined", file.key)
+	}
+
+	route.Uploads[file.key] = file.upload
+
+	return nil
+}
+
+func (file *FileParameter) Handle(_ context.Context, r *request.Request, response *response.Response) error {
+	if err := parseForm(r, response); err != nil {
+		return err
+	}
+
+	if failures := request.FileFailures(r, file.key); len(failures) > 0 {
+		return failures
+	}
+
+	if r.File(file.key) == nil {
+		return request.Failures(fmt.Errorf("file not found: %s", file.key), request.LocationForm, file.key, "required")
+	}
+
+	return nil
+}
+
+func (file *FileParameter) Docs(route *routes.Route) {
+	route.Operation.AddFormField(file.key, &docs.Schema{Type: "string", Format: "binary"}, true,
+		file.upload.AllowedTypes...,
+	)
+
+	route.Operation.Responses[strconv.Itoa(http.StatusUnsupportedMediaType)] = &docs.Response{
+		Description: http.StatusText(http.StatusUnsupportedMediaType),
+	}
+}
+
+func (file *FileParameter) Priority() int {
+	return 100
+}
+
+// File - mandatory file uploaded with multipart form in field 'key'.
+// File is streamed to writer returned by 'open' or to temporary file if 'open' is nil,
+// temporary file is removed after request is served.
+// Files larger than 'maxSize' bytes or having type other than 'allowedTypes' (e.g. 'image/png' or 'image/*')
+// are rejected, type is detected by file's content.
+//
+// Panics if size isn't positive.
+//
+// Result can be retrieved from context using 'context.File'.
+func File(
+	key string,
+	maxSize int64,
+	open func(*request.File) (io.Writer, error),
+	allowedTypes ...string,
+) engi.Middleware {
+	if maxSize <= 0 {
+		panic(fmt.Sprintf("invalid size of file '%s': %d", key, maxSize))
+	}
+
+	return &FileParameter{
+		key: key,
+		upload: request.Upload{
+			MaxSize:      maxSize,
+			AllowedTypes: allowedTypes,
+			Open:         open,
+		},
+	}
+}
+
+// parseForm - reads form body once for all form's parameters.
+func parseForm(r *request.Request, response *response.Response) error {
+	if err := request.ParseForm(r); err != nil {
+		return bodyError(response, err)
+	}
+
+	return nil
+}
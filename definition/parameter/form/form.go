@@ -0,0 +1,194 @@
+// Package form - parameters sent as fields of 'application/x-www-form-urlencoded'
+// or 'multipart/form-data' body and files uploaded with multipart form.
+package form
+
+import (
+	"encoding"
+	"io"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/request"
+)
+
+// Bool - mandatory boolean field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Bool' with 'placing.InForm'.
+func Bool(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Bool(key, placing.InForm, opts...)
+}
+
+// Integer - mandatory integer field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Integer' with 'placing.InForm'.
+func Integer(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Integer(key, placing.InForm, opts...)
+}
+
+// Float - mandatory floating point number field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Float' with 'placing.InForm'.
+func Float(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Float(key, placing.InForm, opts...)
+}
+
+// String - mandatory string field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.String' with 'placing.InForm'.
+func String(key string, opts ...request.Option) engi.Middleware {
+	return parameter.String(key, placing.InForm, opts...)
+}
+
+// Time - mandatory time field of form by 'key' using 'layout'.
+//
+// Result can be retrieved from request using 'Request.Time' with 'placing.InForm'.
+func Time(key, layout string, opts ...request.Option) engi.Middleware {
+	return parameter.Time(key, layout, placing.InForm, opts...)
+}
+
+// Bools - mandatory multi-value boolean field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Bools' with 'placing.InForm'.
+func Bools(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Bools(key, placing.InForm, opts...)
+}
+
+// Integers - mandatory multi-value integer field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Integers' with 'placing.InForm'.
+func Integers(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Integers(key, placing.InForm, opts...)
+}
+
+// Floats - mandatory multi-value floating point number field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Floats' with 'placing.InForm'.
+func Floats(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Floats(key, placing.InForm, opts...)
+}
+
+// Strings - mandatory multi-value string field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Strings' with 'placing.InForm'.
+func Strings(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Strings(key, placing.InForm, opts...)
+}
+
+// Times - mandatory multi-value time field of form by 'key' using 'layout'.
+//
+// Result can be retrieved from request using 'Request.Times' with 'placing.InForm'.
+func Times(key, layout string, opts ...request.Option) engi.Middleware {
+	return parameter.Times(key, layout, placing.InForm, opts...)
+}
+
+// Uint - mandatory unsigned integer field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Uint' with 'placing.InForm'.
+func Uint(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Uint(key, placing.InForm, opts...)
+}
+
+// Duration - mandatory duration field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Duration' with 'placing.InForm'.
+func Duration(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Duration(key, placing.InForm, opts...)
+}
+
+// UUID - mandatory UUID field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.UUID' with 'placing.InForm'.
+func UUID(key string, opts ...request.Option) engi.Middleware {
+	return parameter.UUID(key, placing.InForm, opts...)
+}
+
+// Enum - mandatory string field of form by 'key' which must be one of 'values'.
+//
+// Result can be retrieved from request using 'Request.String' with 'placing.InForm'.
+func Enum(key string, values []string, opts ...request.Option) engi.Middleware {
+	return parameter.Enum(key, values, placing.InForm, opts...)
+}
+
+// Decimal - mandatory arbitrary precision decimal field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Decimal' with 'placing.InForm'.
+func Decimal(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Decimal(key, placing.InForm, opts...)
+}
+
+// IP - mandatory IP address field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.IP' with 'placing.InForm'.
+func IP(key string, opts ...request.Option) engi.Middleware {
+	return parameter.IP(key, placing.InForm, opts...)
+}
+
+// CIDR - mandatory network field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.CIDR' with 'placing.InForm'.
+func CIDR(key string, opts ...request.Option) engi.Middleware {
+	return parameter.CIDR(key, placing.InForm, opts...)
+}
+
+// Email - mandatory email address field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.String' with 'placing.InForm'.
+func Email(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Email(key, placing.InForm, opts...)
+}
+
+// URL - mandatory absolute URL field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.URL' with 'placing.InForm'.
+func URL(key string, opts ...request.Option) engi.Middleware {
+	return parameter.URL(key, placing.InForm, opts...)
+}
+
+// Bytes - mandatory base64 encoded field of form by 'key'.
+//
+// Result can be retrieved from request using 'Request.Bytes' with 'placing.InForm'.
+func Bytes(key string, opts ...request.Option) engi.Middleware {
+	return parameter.Bytes(key, placing.InForm, opts...)
+}
+
+// Custom - mandatory field of form of any type by 'key' converted using 'parse'.
+//
+// Result can be retrieved from request using 'engi.Param[T]' with 'placing.InForm'.
+func Custom[T any](key string, parse func(string) (T, error), opts ...request.Option) engi.Middleware {
+	return parameter.Custom(key, placing.InForm, parse, opts...)
+}
+
+// Text - mandatory field of form by 'key' converted using 'UnmarshalText' method of type 'T'.
+//
+// Result can be retrieved from request using 'engi.Param[T]' with 'placing.InForm'.
+func Text[T any, P interface {
+	*T
+	encoding.TextUnmarshaler
+}](key string, opts ...request.Option) engi.Middleware {
+	return parameter.Text[T, P](key, placing.InForm, opts...)
+}
+
+// File - mandatory file uploaded with multipart form in field 'key', saved to temporary file.
+// Files larger than 'maxSize' bytes or having type other than 'allowedTypes' (e.g. 'image/png' or 'image/*')
+// are rejected, type is detected by file's content. Form with more than 32 files is rejected.
+//
+// Result can be retrieved from request using 'Request.File'.
+func File(key string, maxSize int64, allowedTypes ...string) engi.Middleware {
+	return parameter.File(key, maxSize, nil, allowedTypes...)
+}
+
+// FileTo - mandatory file uploaded with multipart form in field 'key', streamed to writer returned by 'open'.
+// Writer is closed after file is written if it implements 'io.Closer'.
+// Files larger than 'maxSize' bytes or having type other than 'allowedTypes' are rejected,
+// but part of large file can be already written. Form with more than 32 files is rejected.
+//
+// Result can be retrieved from request using 'Request.File'.
+func FileTo(
+	key string,
+	maxSize int64,
+	open func(*engi.File) (io.Writer, error),
+	allowedTypes ...string,
+) engi.Middleware {
+	return parameter.File(key, maxSize, open, allowedTypes...)
+}
@@ -0,0 +1,395 @@
+package parameter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/parameter/form"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+var png = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 8)...)
+
+type uploadService struct {
+	mutex  sync.Mutex
+	paths  []string
+	stored bytes.Buffer
+}
+
+func (s *uploadService) Prefix() string {
+	return "uploads"
+}
+
+func (s *uploadService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.PST("profile"): engi.Handle(s.profile,
+			form.String("name", validate.MaxLen(5)),
+			form.Integers("tags", parameter.Optional()),
+		),
+		engi.PST("avatar"): engi.Handle(s.avatar,
+			form.String("title"),
+			form.File("image", 32, "image/*"),
+		),
+		engi.PST("stream"): engi.Handle(s.stream,
+			form.FileTo("data", 64, func(*engi.File) (io.Writer, error) {
+				return &s.stored, nil
+			}),
+		),
+	}
+}
+
+func (s *uploadService) profile(_ context.Context, req engi.Request, resp engi.Response) error {
+	return resp.OK(map[string]any{
+		"name": req.String("name", placing.InForm),
+		"tags": req.Integers("tags", placing.InForm),
+	})
+}
+
+func (s *uploadService) avatar(_ context.Context, req engi.Request, resp engi.Response) error {
+	var file = req.File("image")
+
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.paths = append(s.paths, file.Path())
+	s.mutex.Unlock()
+
+	return resp.OK(map[string]any{
+		"title":    req.String("title", placing.InForm),
+		"filename": file.Filename,
+		"size":     file.Size,
+		"type":     file.ContentType,
+		"content":  bytes.Equal(content, png),
+	})
+}
+
+func (s *uploadService) stream(_ context.Context, req engi.Request, resp engi.Response) error {
+	var file = req.File("data")
+
+	return resp.OK(map[string]any{
+		"path":   file.Path(),
+		"size":   file.Size,
+		"stored": s.stored.String(),
+	})
+}
+
+type part struct {
+	name, filename string
+	content        []byte
+}
+
+func multipartBody(parts ...part) (string, io.Reader) {
+	var (
+		body   bytes.Buffer
+		writer = multipart.NewWriter(&body)
+	)
+
+	for _, p := range parts {
+		if p.filename == "" {
+			_ = writer.WriteField(p.name, string(p.content))
+
+			continue
+		}
+
+		w, _ := writer.CreateFormFile(p.name, p.filename)
+		_, _ = w.Write(p.content)
+	}
+
+	_ = writer.Close()
+
+	return writer.FormDataContentType(), &body
+}
+
+func TestForm(t *testing.T) {
+	var (
+		service = &uploadService{}
+		engine  = engi.New("", engi.WithMaxBodySize(1024))
+	)
+
+	assert.NoError(t, engine.RegisterServices(service))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	avatar, avatarBody := multipartBody(
+		part{name: "title", content: []byte("me")},
+		part{name: "image", filename: `C:\photos\me.png`, content: png},
+	)
+	text, textBody := multipartBody(
+		part{name: "title", content: []byte("me")},
+		part{name: "image", filename: "me.txt", content: []byte("hello")},
+	)
+	large, largeBody := multipartBody(
+		part{name: "title", content: []byte("me")},
+		part{name: "image", filename: "me.png", content: append(png, make([]byte, 32)...)},
+	)
+	missing, missingReader := multipartBody(part{name: "title", content: []byte("me")})
+	huge, hugeBody := multipartBody(part{name: "title", content: bytes.Repeat([]byte("a"), 2048)})
+	stream, streamBody := multipartBody(part{name: "data", filename: "data.txt", content: []byte("payload")})
+
+	var missingBody = readAll(missingReader)
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        io.Reader
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:        "urlencoded",
+			path:        "profile",
+			contentType: "application/x-www-form-urlencoded",
+			body:        strings.NewReader(url.Values{"name": {"alice"}, "tags": {"1", "2"}}.Encode()),
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"name":"alice","tags":[1,2]}`,
+		},
+		{
+			name:        "multipart fields",
+			path:        "profile",
+			contentType: missing,
+			body:        strings.NewReader(strings.ReplaceAll(missingBody, "title", "name")),
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"name":"me","tags":null}`,
+		},
+		{
+			name:        "invalid field",
+			path:        "profile",
+			contentType: "application/x-www-form-urlencoded",
+			body:        strings.NewReader("name=alexander&tags=x"),
+			wantStatus:  http.StatusBadRequest,
+			wantBody: `[
+				{"location":"form","field":"name","rule":"max_len","message":"'name' should have length at most 5"},
+				{"location":"form","field":"tags[0]","rule":"type","message":"can't convert parameter 'tags[0]': strconv.ParseInt: parsing \"x\": invalid syntax"}
+			]`,
+		},
+		{
+			name:        "file",
+			path:        "avatar",
+			contentType: avatar,
+			body:        avatarBody,
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"title":"me","filename":"me.png","size":16,"type":"image/png","content":true}`,
+		},
+		{
+			name:        "file type",
+			path:        "avatar",
+			contentType: text,
+			body:        textBody,
+			wantStatus:  http.StatusBadRequest,
+			wantBody: `[{"location":"form","field":"image","rule":"content_type",
+				"message":"file 'image' has type 'text/plain', allowed: image/*"}]`,
+		},
+		{
+			name:        "file too large",
+			path:        "avatar",
+			contentType: large,
+			body:        largeBody,
+			wantStatus:  http.StatusBadRequest,
+			wantBody: `[{"location":"form","field":"image","rule":"max_size",
+				"message":"file too large: 'image' exceeds 32 bytes"}]`,
+		},
+		{
+			name:        "no file",
+			path:        "avatar",
+			contentType: missing,
+			body:        strings.NewReader(missingBody),
+			wantStatus:  http.StatusBadRequest,
+			wantBody:    `[{"location":"form","field":"image","rule":"required","message":"file not found: image"}]`,
+		},
+		{
+			name:        "body too large",
+			path:        "avatar",
+			contentType: huge,
+			body:        reader{hugeBody},
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantBody:    `"request body too large: limit is 1024 bytes"`,
+		},
+		{
+			name:        "not a form",
+			path:        "avatar",
+			contentType: "application/json",
+			body:        strings.NewReader(`{"title":"me"}`),
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantBody:    `"request body is not a form: got 'application/json'"`,
+		},
+		{
+			name:        "writer",
+			path:        "stream",
+			contentType: stream,
+			body:        streamBody,
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"path":"","size":7,"stored":"payload"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Post(server.URL+"/uploads/"+tt.path, tt.contentType, tt.body)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+		assert.JSONEq(t, tt.wantBody, string(body), tt.name)
+	}
+
+	// Temporary files are removed after request is served.
+	assert.Len(t, service.paths, 1)
+
+	for _, path := range service.paths {
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err), path)
+	}
+}
+
+func TestFormMemory(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&uploadService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	var field = bytes.Repeat([]byte("a"), 6<<20)
+
+	undeclared, undeclaredBody := multipartBody(
+		part{name: "name", content: []byte("me")},
+		part{name: "other", content: field},
+		part{name: "other", content: field},
+	)
+	large, largeBody := multipartBody(
+		part{name: "name", content: field},
+		part{name: "name", content: field},
+	)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        io.Reader
+		wantStatus  int
+		wantBody    string
+	}{
+		// Fields no parameter reads are skipped and don't count against limit.
+		{"undeclared", undeclared, undeclaredBody, http.StatusOK, `{"name":"me","tags":null}`},
+		{"too large", large, largeBody, http.StatusRequestEntityTooLarge,
+			`"request body too large: form fields exceed 10485760 bytes"`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Post(server.URL+"/uploads/profile", tt.contentType, tt.body)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+		assert.JSONEq(t, tt.wantBody, string(body), tt.name)
+	}
+}
+
+func TestFormFiles(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&uploadService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	var parts = []part{{name: "title", content: []byte("me")}}
+	for i := 0; i < 33; i++ {
+		parts = append(parts, part{name: "image", filename: "me.png", content: png})
+	}
+
+	contentType, body := multipartBody(parts...)
+
+	resp, err := http.Post(server.URL+"/uploads/avatar", contentType, body)
+	assert.NoError(t, err)
+
+	result, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.JSONEq(t, `"request body too large: form has more than 32 files"`, string(result))
+}
+
+func readAll(r io.Reader) string {
+	data, _ := io.ReadAll(r)
+
+	return string(data)
+}
+
+func TestFormDocs(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&uploadService{}))
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	type schema struct {
+		Required   []string                  `json:"required"`
+		Properties map[string]map[string]any `json:"properties"`
+	}
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			Parameters  []json.RawMessage `json:"parameters"`
+			RequestBody struct {
+				Content map[string]struct {
+					Schema   schema                       `json:"schema"`
+					Encoding map[string]map[string]string `json:"encoding"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	var profile = document.Paths["/uploads/profile"]["post"]
+	assert.Empty(t, profile.Parameters)
+	assert.Len(t, profile.RequestBody.Content, 2)
+
+	for _, media := range profile.RequestBody.Content {
+		assert.Equal(t, []string{"name"}, media.Schema.Required)
+		assert.Equal(t, "string", media.Schema.Properties["name"]["type"])
+		assert.Equal(t, "array", media.Schema.Properties["tags"]["type"])
+	}
+
+	var avatar = document.Paths["/uploads/avatar"]["post"]
+	assert.Len(t, avatar.RequestBody.Content, 1)
+
+	var media = avatar.RequestBody.Content["multipart/form-data"]
+	assert.Equal(t, []string{"title", "image"}, media.Schema.Required)
+	assert.Equal(t, map[string]any{"type": "string", "format": "binary"}, media.Schema.Properties["image"])
+	assert.Equal(t, map[string]string{"contentType": "image/*"}, media.Encoding["image"])
+
+	assert.Panics(t, func() { form.File("image", 0) })
+}
@@ -0,0 +1,143 @@
+package parameter
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/request"
+)
+
+// Uint - mandatory unsigned integer Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.Uint'.
+func Uint(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "uint64", `(\d+)`,
+		func(p string) (uint64, error) {
+			return strconv.ParseUint(p, request.IntBase, request.BitSize)
+		},
+		options,
+	)
+}
+
+// Duration - mandatory duration Parameter from request by 'key' (e.g. '1h30m', '250ms').
+//
+// Result can be retrieved from context using 'context.QueryParams.Duration'.
+func Duration(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "duration", `((\+|-)?(0|(\d+(\.\d+)?(ns|us|µs|ms|s|m|h))+))`,
+		time.ParseDuration,
+		options,
+	)
+}
+
+// UUID - mandatory UUID Parameter from request by 'key' in canonical form.
+//
+// Result can be retrieved from context using 'context.QueryParams.UUID'.
+func UUID(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "uuid",
+		`([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})`,
+		request.ParseUUID,
+		options,
+	)
+}
+
+// Enum - mandatory string Parameter from request by 'key' which must be one of 'values'.
+//
+// Result can be retrieved from context using 'context.QueryParams.String'.
+func Enum(key string, values []string, place placing.Placing, options ...request.Option) engi.Middleware {
+	if len(values) == 0 {
+		panic(fmt.Sprintf("enum parameter '%s' has no values", key))
+	}
+
+	var quoted = make([]string, len(values))
+
+	for i, value := range values {
+		quoted[i] = regexp.QuoteMeta(value)
+	}
+
+	var parameter = newParameter(key, place, "enum", "("+strings.Join(quoted, "|")+")",
+		func(p string) (string, error) {
+			if !slices.Contains(values, p) {
+				return "", fmt.Errorf("value '%s' should be one of: %s", p, strings.Join(values, ", "))
+			}
+
+			return p, nil
+		},
+		options,
+	)
+
+	parameter.enum = values
+
+	return parameter
+}
+
+// Decimal - mandatory arbitrary precision decimal Parameter from request by 'key' (e.g. '-12.345').
+// Value is kept in textual form, so no precision is lost.
+//
+// Result can be retrieved from context using 'context.QueryParams.Decimal'.
+func Decimal(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "decimal", `((\+|-)?\d+(\.\d+)?)`,
+		request.ParseDecimal,
+		options,
+	)
+}
+
+// IP - mandatory IPv4 or IPv6 address Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.IP'.
+func IP(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "ip", `([0-9a-fA-F:.]+)`,
+		netip.ParseAddr,
+		options,
+	)
+}
+
+// CIDR - mandatory network Parameter from request by 'key' (e.g. '10.0.0.0/8').
+//
+// Result can be retrieved from context using 'context.QueryParams.CIDR'.
+func CIDR(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "cidr", `([0-9a-fA-F:.]+/\d{1,3})`,
+		netip.ParsePrefix,
+		options,
+	)
+}
+
+// Email - mandatory email address Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.String'.
+func Email(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "email", `([^@\s]+@[^@\s]+)`,
+		request.ParseEmail,
+		options,
+	)
+}
+
+// URL - mandatory absolute URL Parameter from request by 'key'.
+//
+// Result can be retrieved from context using 'context.QueryParams.URL'.
+func URL(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "url", `([a-zA-Z][a-zA-Z0-9+.-]*://\S+)`,
+		func(p string) (*url.URL, error) {
+			return request.ParseURL(p)
+		},
+		options,
+	)
+}
+
+// Bytes - mandatory base64 encoded Parameter from request by 'key'.
+// Standard and URL-safe alphabets are accepted, URL-safe one should be used in query and path.
+//
+// Result can be retrieved from context using 'context.QueryParams.Bytes'.
+func Bytes(key string, place placing.Placing, options ...request.Option) engi.Middleware {
+	return newParameter(key, place, "byte", `([A-Za-z0-9+/_-]*={0,2})`,
+		request.ParseBytes,
+		options,
+	)
+}
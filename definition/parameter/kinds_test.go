@@ -0,0 +1,153 @@
+package parameter_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/parameter/path"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/definition/parameter/query"
+	"github.com/stretchr/testify/assert"
+)
+
+type kindsService struct{}
+
+func (s *kindsService) Prefix() string {
+	return "kinds"
+}
+
+func (s *kindsService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET(":id"): engi.Handle(s.get,
+			path.UUID("id"),
+			query.Enum("order", []string{"asc", "desc"}, parameter.Default("asc")),
+			query.Duration("timeout", parameter.Optional()),
+			query.Uint("page", parameter.Optional()),
+			query.Decimal("amount", parameter.Optional()),
+			query.IP("ip", parameter.Optional()),
+			query.CIDR("net", parameter.Optional()),
+			query.Email("email", parameter.Optional()),
+			query.URL("callback", parameter.Optional()),
+			query.Bytes("data", parameter.Optional()),
+		),
+	}
+}
+
+func (s *kindsService) get(_ context.Context, req engi.Request, resp engi.Response) error {
+	var callback string
+	if url := req.URL("callback", placing.InQuery); url != nil {
+		callback = url.Host
+	}
+
+	return resp.OK(map[string]any{
+		"id":       req.UUID("id", placing.InPath).String(),
+		"order":    req.String("order", placing.InQuery),
+		"timeout":  req.Duration("timeout", placing.InQuery).String(),
+		"page":     req.Uint("page", placing.InQuery),
+		"amount":   req.Decimal("amount", placing.InQuery),
+		"ip":       req.IP("ip", placing.InQuery).String(),
+		"net":      req.CIDR("net", placing.InQuery).String(),
+		"email":    req.String("email", placing.InQuery),
+		"callback": callback,
+		"data":     string(req.Bytes("data", placing.InQuery)),
+	})
+}
+
+func TestParameterKinds(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&kindsService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	const id = "/kinds/6BA7B810-9DAD-11D1-80B4-00C04FD430C8"
+
+	tests := []struct {
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			url:        id,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"amount":"","callback":"","data":"","email":"","id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","ip":"invalid IP","net":"invalid Prefix","order":"asc","page":0,"timeout":"0s"}`,
+		},
+		{
+			url: id + "?order=desc&timeout=1m30s&page=7&amount=123456789.000000001&ip=::1&net=10.0.0.0/8" +
+				"&email=user@example.com&callback=https://example.com/hook&data=aGVsbG8",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"amount":"123456789.000000001","callback":"example.com","data":"hello","email":"user@example.com","id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","ip":"::1","net":"10.0.0.0/8","order":"desc","page":7,"timeout":"1m30s"}`,
+		},
+		{"/kinds/123", http.StatusBadRequest, `[{"location":"path","field":"id","rule":"type","message":"can't convert parameter 'id': invalid UUID: '123'"}]`},
+		{id + "?order=up", http.StatusBadRequest, `[{"location":"query","field":"order","rule":"type","message":"can't convert parameter 'order': value 'up' should be one of: asc, desc"}]`},
+		{id + "?page=-1", http.StatusBadRequest, `[{"location":"query","field":"page","rule":"type","message":"can't convert parameter 'page': strconv.ParseUint: parsing \"-1\": invalid syntax"}]`},
+		{id + "?amount=1e3", http.StatusBadRequest, `[{"location":"query","field":"amount","rule":"type","message":"can't convert parameter 'amount': invalid decimal: '1e3'"}]`},
+		{id + "?amount=0x10", http.StatusBadRequest, `[{"location":"query","field":"amount","rule":"type","message":"can't convert parameter 'amount': invalid decimal: '0x10'"}]`},
+		{id + "?amount=0x1p4", http.StatusBadRequest, `[{"location":"query","field":"amount","rule":"type","message":"can't convert parameter 'amount': invalid decimal: '0x1p4'"}]`},
+		{id + "?amount=0b101", http.StatusBadRequest, `[{"location":"query","field":"amount","rule":"type","message":"can't convert parameter 'amount': invalid decimal: '0b101'"}]`},
+		{id + "?amount=1_000", http.StatusBadRequest, `[{"location":"query","field":"amount","rule":"type","message":"can't convert parameter 'amount': invalid decimal: '1_000'"}]`},
+		{id + "?amount=.5", http.StatusBadRequest, `[{"location":"query","field":"amount","rule":"type","message":"can't convert parameter 'amount': invalid decimal: '.5'"}]`},
+		{id + "?amount=1.", http.StatusBadRequest, `[{"location":"query","field":"amount","rule":"type","message":"can't convert parameter 'amount': invalid decimal: '1.'"}]`},
+		{id + "?email=user.example.com", http.StatusBadRequest, `[{"location":"query","field":"email","rule":"type","message":"can't convert parameter 'email': invalid email: 'user.example.com'"}]`},
+		{id + "?callback=/relative", http.StatusBadRequest, `[{"location":"query","field":"callback","rule":"type","message":"can't convert parameter 'callback': invalid URL: '/relative'"}]`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(server.URL + tt.url)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.url)
+		assert.Equal(t, tt.wantBody, string(body), tt.url)
+	}
+}
+
+func TestParameterKindsDocs(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&kindsService{}))
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name   string         `json:"name"`
+				Schema map[string]any `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	var schemas = make(map[string]map[string]any)
+
+	for _, parameter := range document.Paths["/kinds/{id}"]["get"].Parameters {
+		schemas[parameter.Name] = parameter.Schema
+	}
+
+	// 'ip' isn't format of OpenAPI, address is described by pattern.
+	assert.Equal(t, "string", schemas["ip"]["type"])
+	assert.NotContains(t, schemas["ip"], "format")
+	assert.NotEmpty(t, schemas["ip"]["pattern"])
+
+	var duration = regexp.MustCompile(`^(?:` + schemas["timeout"]["pattern"].(string) + `)$`)
+
+	for _, value := range []string{"0", "-0", "1h30m", "1.5s", "250ms"} {
+		assert.True(t, duration.MatchString(value), value)
+	}
+
+	assert.False(t, duration.MatchString("1"))
+}
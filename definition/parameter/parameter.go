@@ -2,12 +2,15 @@ package parameter
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/kliuchnikovv/engi"
 	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/response"
 	"github.com/kliuchnikovv/engi/internal/routes"
@@ -20,9 +23,90 @@ type Parameter struct {
 	placing placing.Placing
 	options []request.Option
 
+	optional     bool
+	defaultValue any
+
 	typeName string
 	regexp   string
 	parse    func(string) (any, error)
+	// valueType - type of parsed value (of each value for multi-value parameter).
+	valueType reflect.Type
+	// enum - allowed values of enum parameter.
+	enum []string
+	// custom - schema of custom parameter's value.
+	custom *docs.Schema
+
+	// array - settings of multi-value parameter, nil for single value parameter.
+	array *array
+}
+
+// setting - option changing parameter's extraction instead of validating its value.
+type setting func(*Parameter)
+
+func (setting) Validate(*request.Parameter) error {
+	return nil
+}
+
+// Optional - makes parameter optional: request without it won't be rejected.
+// Value that was sent still must be valid.
+func Optional() request.Option {
+	return setting(func(parameter *Parameter) {
+		parameter.optional = true
+	})
+}
+
+// Default - makes parameter optional with default value used if parameter wasn't sent.
+// Value must be of parameter's type or its string representation.
+func Default(value any) request.Option {
+	return setting(func(parameter *Parameter) {
+		parameter.optional = true
+		parameter.defaultValue = value
+	})
+}
+
+func newParameter[T any](
+	key string,
+	place placing.Placing,
+	typeName, regexp string,
+	parse func(string) (T, error),
+	options []request.Option,
+) *Parameter {
+	var parameter = Parameter{
+		key:       key,
+		placing:   place,
+		typeName:  typeName,
+		regexp:    regexp,
+		valueType: reflect.TypeFor[T](),
+		parse: func(value string) (any, error) {
+			return parse(value)
+		},
+	}
+
+	parameter.configure(options)
+
+	if parameter.defaultValue != nil {
+		if _, ok := parameter.defaultValue.(T); !ok {
+			typed, err := parse(fmt.Sprint(parameter.defaultValue))
+			if err != nil {
+				panic(fmt.Sprintf("invalid default value of parameter '%s': %s", key, err))
+			}
+
+			parameter.defaultValue = typed
+		}
+	}
+
+	return &parameter
+}
+
+// configure - applies settings and keeps other options as validators.
+func (parameter *Parameter) configure(options []request.Option) {
+	for _, option := range options {
+		if setting, ok := option.(setting); ok {
+			setting(parameter)
+		} else {
+			parameter.options = append(parameter.options, option)
+		}
+	}
 }
 
 func (parameter Parameter) Name() string {
@@ -33,21 +117,45 @@ func (parameter Parameter) Regexp() string {
 	return parameter.regexp
 }
 
-// func (parameter Parameter) Bind(route *routes.Route) error {
-// 	// if _, ok := route.Params[parameter.placing]; !ok {
-// 	// 	route.Params[parameter.placing] = make(map[string]routes.Middleware)
-// 	// }
+// Bind - checks that validators can validate parameter's values,
+// form fields are registered, so only they are read from form.
+func (parameter Parameter) Bind(route *routes.Route) error {
+	if err := request.Check(parameter.valueType, parameter.options); err != nil {
+		return fmt.Errorf("parameter '%s': %w", parameter.key, err)
+	}
 
-// 	// route.Params[parameter.placing][parameter.key] = parameter
+	if parameter.placing == placing.InForm {
+		if route.Fields == nil {
+			route.Fields = make(map[string]struct{})
+		}
 
-// 	return nil
-// }
+		route.Fields[parameter.key] = struct{}{}
+	}
+
+	return nil
+}
 
 func (parameter Parameter) Handle(
 	ctx context.Context,
 	r *request.Request,
 	response *response.Response,
 ) error {
+	if parameter.placing == placing.InForm {
+		if err := parseForm(r, response); err != nil {
+			return err
+		}
+	}
+
+	if parameter.array != nil {
+		return parameter.extractArray(r)
+	}
+
+	if parameter.optional && !r.Has(parameter.key, parameter.placing) {
+		request.SetParsed(r, parameter.key, parameter.placing, parameter.defaultValue)
+
+		return nil
+	}
+
 	return request.ExtractParam(r,
 		parameter.key,
 		parameter.placing,
@@ -57,7 +165,76 @@ func (parameter Parameter) Handle(
 }
 
 func (parameter Parameter) Docs(route *routes.Route) {
-	panic("not implemented")
+	var (
+		schema = parameter.schema()
+		param  = docs.Parameter{
+			Name:     parameter.key,
+			In:       string(parameter.placing),
+			Required: !parameter.optional || parameter.placing == placing.InPath,
+			Schema:   schema,
+		}
+	)
+
+	if parameter.array != nil {
+		param.Schema = &docs.Schema{
+			Type:     "array",
+			Items:    schema,
+			MinItems: parameter.array.minItems,
+			MaxItems: parameter.array.maxItems,
+		}
+		param.Style, param.Explode = parameter.array.style.docs(parameter.placing)
+	}
+
+	param.Schema.Default = parameter.defaultValue
+
+	if parameter.placing == placing.InForm {
+		route.Operation.AddFormField(param.Name, param.Schema, param.Required)
+
+		return
+	}
+
+	route.Operation.AddParameter(&param)
+}
+
+// schema - returns schema of parameter's value.
+func (parameter Parameter) schema() *docs.Schema {
+	if parameter.custom != nil {
+		var schema = *parameter.custom
+
+		return &schema
+	}
+
+	var schema docs.Schema
+
+	switch parameter.typeName {
+	case "bool":
+		schema.Type = "boolean"
+	case "int64":
+		schema.Type, schema.Format = "integer", "int64"
+	case "float64":
+		schema.Type, schema.Format = "number", "double"
+	case "uint64":
+		schema.Type, schema.Format = "integer", "int64"
+	// OpenAPI formats of addresses are 'ipv4' and 'ipv6', parameter accepts both.
+	case "time", "duration", "ip":
+		schema.Type, schema.Pattern = "string", parameter.regexp
+	case "enum":
+		schema.Type = "string"
+
+		for _, value := range parameter.enum {
+			schema.Enum = append(schema.Enum, value)
+		}
+	case "uuid", "email", "cidr", "byte":
+		schema.Type, schema.Format = "string", parameter.typeName
+	case "decimal":
+		schema.Type, schema.Format, schema.Pattern = "string", "decimal", parameter.regexp
+	case "url":
+		schema.Type, schema.Format = "string", "uri"
+	default:
+		schema.Type = "string"
+	}
+
+	return &schema
 }
 
 func (parameter Parameter) Priority() int {
@@ -68,93 +245,73 @@ func (parameter Parameter) Priority() int {
 //
 // Result can be retrieved from context using 'context.QueryParams.Bool'.
 func Bool(key string, place placing.Placing, options ...request.Option) engi.Middleware {
-	return &Parameter{
-		key:      key,
-		placing:  place,
-		options:  options,
-		typeName: "bool",
-		regexp:   `(1|t|T|TRUE|true|True|0|f|F|FALSE|false|False)`,
-		parse: func(request string) (any, error) {
+	return newParameter(key, place, "bool", `(1|t|T|TRUE|true|True|0|f|F|FALSE|false|False)`,
+		func(request string) (bool, error) {
 			return strconv.ParseBool(request)
 		},
-	}
+		options,
+	)
 }
 
 // Integer - queries mandatory integer Parameter from request by 'key'.
 //
 // Result can be retrieved from context using 'context.QueryParams.Integer'.
 func Integer(key string, place placing.Placing, options ...request.Option) engi.Middleware {
-	return &Parameter{
-		key:      key,
-		placing:  place,
-		options:  options,
-		typeName: "int64",
-		regexp:   `((\+|-)?\d+)`,
-		parse: func(p string) (interface{}, error) {
+	return newParameter(key, place, "int64", `((\+|-)?\d+)`,
+		func(p string) (int64, error) {
 			result, err := strconv.ParseInt(p, request.IntBase, request.BitSize)
 			if err != nil {
-				return nil, err //response.BadRequest("Parameter '%s' not of type int (got: '%s')", key, p)
+				return 0, err //response.BadRequest("Parameter '%s' not of type int (got: '%s')", key, p)
 			}
 
 			return result, nil
 		},
-	}
+		options,
+	)
 }
 
 // Float - mandatory floating point number Parameter from request by 'key'.
 //
 // Result can be retrieved from context using 'context.QueryParams.Float'.
 func Float(key string, place placing.Placing, options ...request.Option) engi.Middleware {
-	return &Parameter{
-		key:      key,
-		placing:  place,
-		options:  options,
-		typeName: "float64",
-		regexp:   `((+|-)\d+(\.\d+)?)`,
-		parse: func(p string) (interface{}, error) {
+	return newParameter(key, place, "float64", `((\+|-)?\d+(\.\d+)?)`,
+		func(p string) (float64, error) {
 			result, err := strconv.ParseFloat(p, request.BitSize)
 			if err != nil {
-				return nil, err //response.BadRequest("Parameter '%s' not of type float (got: '%s')", key, p)
+				return 0, err //response.BadRequest("Parameter '%s' not of type float (got: '%s')", key, p)
 			}
 
 			return result, nil
 		},
-	}
+		options,
+	)
 }
 
 // String - mandatory string Parameter from request by 'key'.
 //
 // Result can be retrieved from context using 'context.QueryParams.String'.
 func String(key string, place placing.Placing, options ...request.Option) engi.Middleware {
-	return &Parameter{
-		key:      key,
-		placing:  place,
-		options:  options,
-		typeName: "string",
-		regexp:   `(.+)`,
-		parse: func(p string) (interface{}, error) {
+	return newParameter(key, place, "string", `(.+)`,
+		func(p string) (string, error) {
 			return p, nil
 		},
-	}
+		options,
+	)
 }
 
 // Time - mandatory time Parameter from request by 'key' using 'layout'.
 //
 // Result can be retrieved from context using 'context.QueryParams.Time'.
 func Time(key, layout string, place placing.Placing, options ...request.Option) engi.Middleware {
-	return &Parameter{
-		key:      key,
-		placing:  place,
-		options:  options,
-		typeName: "time",
-		regexp:   numRegexp.ReplaceAllString(layout, `\d`),
-		parse: func(request string) (interface{}, error) {
+	return newParameter(key, place, "time", numRegexp.ReplaceAllString(layout, `\d`),
+		func(request string) (time.Time, error) {
 			result, err := time.Parse(layout, request)
 			if err != nil {
-				return nil, err //response.BadRequest("could not parse '%s' request to datetime using '%s' layout", key, layout)
+				return time.Time{}, err //response.BadRequest("could not parse '%s' request to datetime using '%s' layout", key, layout)
 			}
 
 			return result, nil
 		},
-	}
+		options,
+	)
 }
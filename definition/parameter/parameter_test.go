@@ -0,0 +1,206 @@
+package parameter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/definition/parameter/query"
+	"github.com/stretchr/testify/assert"
+)
+
+type listService struct{}
+
+func (s *listService) Prefix() string {
+	return "list"
+}
+
+func (s *listService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET(""): engi.Handle(s.list,
+			query.Integer("limit", parameter.Optional(), parameter.Default(20)),
+			query.String("cursor", parameter.Optional()),
+		),
+		// Parameter is got as another type than it was requested with.
+		engi.GET("page"): engi.Handle(
+			func(_ context.Context, req engi.Request, resp engi.Response) error {
+				return resp.OK(req.Integer("page", placing.InQuery))
+			},
+			query.String("page"),
+		),
+		engi.GET("filter"): engi.Handle(s.filter,
+			query.Integers("id", parameter.MaxItems(3)),
+			query.Strings("tag", parameter.Style(parameter.Comma), parameter.Default([]string{"all"})),
+		),
+		engi.GET("ids"): engi.Handle(
+			func(_ context.Context, req engi.Request, resp engi.Response) error {
+				return resp.OK(map[string]any{
+					"id":     req.Integers("id", placing.InQuery),
+					"has_id": req.Has("id", placing.InQuery),
+				})
+			},
+			query.Integers("id", parameter.Optional()),
+		),
+	}
+}
+
+func (s *listService) list(_ context.Context, req engi.Request, resp engi.Response) error {
+	return resp.OK(map[string]any{
+		"limit":     req.Integer("limit", placing.InQuery),
+		"has_limit": req.Has("limit", placing.InQuery),
+		"cursor":    req.String("cursor", placing.InQuery),
+	})
+}
+
+func (s *listService) filter(_ context.Context, req engi.Request, resp engi.Response) error {
+	return resp.OK(map[string]any{
+		"id":  req.Integers("id", placing.InQuery),
+		"tag": req.Strings("tag", placing.InQuery),
+	})
+}
+
+func TestOptionalParameters(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&listService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{"", http.StatusOK, `{"cursor":"","has_limit":false,"limit":20}`},
+		{"?limit=5&cursor=abc", http.StatusOK, `{"cursor":"abc","has_limit":true,"limit":5}`},
+		{"?limit=abc", http.StatusBadRequest, `[{"location":"query","field":"limit","rule":"type","message":"can't convert parameter 'limit': strconv.ParseInt: parsing \"abc\": invalid syntax"}]`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(server.URL + "/list/" + tt.query)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.query)
+		assert.Equal(t, tt.wantBody, string(body), tt.query)
+	}
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name     string         `json:"name"`
+				Required bool           `json:"required"`
+				Schema   map[string]any `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	var parameters = document.Paths["/list/"]["get"].Parameters
+	assert.Len(t, parameters, 2)
+	assert.Equal(t, "limit", parameters[0].Name)
+	assert.False(t, parameters[0].Required)
+	assert.Equal(t, float64(20), parameters[0].Schema["default"])
+}
+
+func TestParameterTypeMismatch(t *testing.T) {
+	var (
+		logs   bytes.Buffer
+		engine = engi.New("", engi.WithLogger(slog.NewTextHandler(&logs, nil)))
+	)
+
+	assert.NoError(t, engine.RegisterServices(&listService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/list/page?page=3")
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	// Sent value is converted instead of panic, misuse is logged.
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "3", string(body))
+	assert.Contains(t, logs.String(), "parameter was requested with another type")
+	assert.Contains(t, logs.String(), "requested=string got_as=int64")
+}
+
+func TestArrayParameters(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&listService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{"filter?id=1&id=2", http.StatusOK, `{"id":[1,2],"tag":["all"]}`},
+		{"filter?id=3&tag=a,b&tag=c", http.StatusOK, `{"id":[3],"tag":["a","b","c"]}`},
+		{"filter", http.StatusBadRequest, `[{"location":"query","field":"id","rule":"required","message":"parameter not found: id"}]`},
+		{"filter?id=1&id=x", http.StatusBadRequest, `[{"location":"query","field":"id[1]","rule":"type","message":"can't convert parameter 'id[1]': strconv.ParseInt: parsing \"x\": invalid syntax"}]`},
+		{"filter?id=1&id=2&id=3&id=4", http.StatusBadRequest, `[{"location":"query","field":"id","rule":"max_items","message":"parameter 'id' should have at most 3 items"}]`},
+		{"filter?id=", http.StatusBadRequest, `[{"location":"query","field":"id","rule":"required","message":"parameter not found: id"}]`},
+		// Empty value of optional parameter is the same as absent one.
+		{"ids?id=", http.StatusOK, `{"has_id":false,"id":null}`},
+		{"ids?id=&id=2", http.StatusOK, `{"has_id":true,"id":[2]}`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(server.URL + "/list/" + tt.query)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.query)
+		assert.Equal(t, tt.wantBody, string(body), tt.query)
+	}
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name    string         `json:"name"`
+				Style   string         `json:"style"`
+				Explode bool           `json:"explode"`
+				Schema  map[string]any `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	var parameters = document.Paths["/list/filter"]["get"].Parameters
+	assert.Len(t, parameters, 2)
+	assert.Equal(t, "array", parameters[0].Schema["type"])
+	assert.Equal(t, float64(3), parameters[0].Schema["maxItems"])
+	assert.True(t, parameters[0].Explode)
+	assert.Equal(t, "form", parameters[1].Style)
+	assert.False(t, parameters[1].Explode)
+}
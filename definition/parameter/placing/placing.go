@@ -7,4 +7,6 @@ const (
 	InQuery  Placing = "query"
 	InCookie Placing = "cookie"
 	InHeader Placing = "header"
+	// InForm - field of 'application/x-www-form-urlencoded' or 'multipart/form-data' body.
+	InForm Placing = "form"
 )
@@ -0,0 +1,110 @@
+package parameter_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/parameter/cookie"
+	"github.com/kliuchnikovv/engi/definition/parameter/header"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type placingService struct{}
+
+func (s *placingService) Prefix() string {
+	return "placing"
+}
+
+func (s *placingService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET(""): engi.Handle(s.get,
+			header.Integer("x-page", validate.Greater(0)),
+			header.Strings("X-Tags", parameter.Optional()),
+			cookie.UUID("session"),
+		),
+	}
+}
+
+func (s *placingService) get(_ context.Context, req engi.Request, resp engi.Response) error {
+	return resp.OK(map[string]any{
+		"page":    req.Integer("X-Page", placing.InHeader),
+		"tags":    req.Strings("x-tags", placing.InHeader),
+		"session": req.UUID("session", placing.InCookie).String(),
+	})
+}
+
+func TestHeaderAndCookieParameters(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&placingService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	const session = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+	tests := []struct {
+		name       string
+		headers    map[string][]string
+		cookie     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "all parameters",
+			headers:    map[string][]string{"X-PAGE": {"2"}, "x-tags": {"a, b", "c"}},
+			cookie:     session,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"page":2,"session":"` + session + `","tags":["a","b","c"]}`,
+		},
+		{
+			name:       "optional header",
+			headers:    map[string][]string{"X-Page": {"1"}},
+			cookie:     session,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"page":1,"session":"` + session + `","tags":null}`,
+		},
+		{
+			name:       "header validated",
+			headers:    map[string][]string{"X-Page": {"0"}},
+			cookie:     session,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `[{"location":"header","field":"X-Page","rule":"gt","message":"'X-Page' should be greater than 0"}]`,
+		},
+		{
+			name:       "invalid cookie",
+			headers:    map[string][]string{"X-Page": {"1"}},
+			cookie:     "abc",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `[{"location":"cookie","field":"session","rule":"type","message":"can't convert parameter 'session': invalid UUID: 'abc'"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/placing/", nil)
+		assert.NoError(t, err)
+
+		for key, values := range tt.headers {
+			req.Header[key] = values
+		}
+
+		req.AddCookie(&http.Cookie{Name: "session", Value: tt.cookie})
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+		assert.Equal(t, tt.wantBody, string(body), tt.name)
+	}
+}
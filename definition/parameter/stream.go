@@ -0,0 +1,147 @@
+package parameter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/kliuchnikovv/engi/internal/docs"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+// ndjsonTypes - media types of newline-delimited JSON.
+var ndjsonTypes = []string{"application/x-ndjson", "application/jsonl", "application/json-lines"}
+
+type streamBody struct {
+	contentTypes []string
+}
+
+func (body *streamBody) Handle(_ context.Context, r *request.Request, response *response.Response) error {
+	stream, err := request.OpenBody(r)
+	if err != nil {
+		return bodyError(response, err)
+	}
+
+	request.SetStream(r, stream)
+
+	return nil
+}
+
+func (body *streamBody) Docs(route *routes.Route) {
+	streamDocs(route, body.contentTypes, &docs.Schema{Type: "string", Format: "binary"})
+}
+
+func (body *streamBody) Priority() int {
+	return 100
+}
+
+// StreamBody - gives handler reader of request body instead of reading whole body before handler is called,
+// so bodies of any size can be processed part by part.
+// Body compressed with 'Content-Encoding' 'gzip' or 'zstd' is decoded, size of body is limited by
+// 'parameter.MaxBodySize' or 'engi.WithMaxBodySize', without them decoded body is limited by 32MB.
+// 'contentTypes' are used only for documentation, 'application/octet-stream' by default.
+//
+// Result can be retrieved from context using 'context.Stream'.
+func StreamBody(contentTypes ...string) engi.Middleware {
+	if len(contentTypes) == 0 {
+		contentTypes = []string{"application/octet-stream"}
+	}
+
+	return &streamBody{
+		contentTypes: contentTypes,
+	}
+}
+
+type ndjsonBody[T any] struct {
+	options []request.Option
+}
+
+// Bind - checks that validators can validate items.
+func (body *ndjsonBody[T]) Bind(*routes.Route) error {
+	if err := request.Check(reflect.TypeFor[*T](), body.options); err != nil {
+		return fmt.Errorf("body: %w", err)
+	}
+
+	return nil
+}
+
+func (body *ndjsonBody[T]) Handle(_ context.Context, r *request.Request, response *response.Response) error {
+	if contentType := r.GetRequest().Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !slices.Contains(ndjsonTypes, mediaType) {
+			return response.UnsupportedMediaType("unsupported media type: '%s', supported: %s",
+				contentType, strings.Join(ndjsonTypes, ", "),
+			)
+		}
+	}
+
+	stream, err := request.OpenBody(r)
+	if err != nil {
+		return bodyError(response, err)
+	}
+
+	request.SetStream(r, stream)
+	request.SetBody(r, request.NewItems[T](stream, body.options))
+
+	return nil
+}
+
+func (body *ndjsonBody[T]) Docs(route *routes.Route) {
+	streamDocs(route, ndjsonTypes[:1], docs.SchemaOf(reflect.TypeFor[T]()))
+}
+
+func (body *ndjsonBody[T]) Priority() int {
+	return 100
+}
+
+// NDJSON - gives handler items of newline-delimited JSON body decoded one at a time,
+// so ingestion of large bodies doesn't need memory for whole body.
+// Items are read only when handler requests next one, reading stops when request's context is cancelled.
+// Every item is validated using 'validate' tags (see 'validate.Struct') and 'options'.
+// Body compressed with 'Content-Encoding' 'gzip' or 'zstd' is decoded, size of body is limited by
+// 'parameter.MaxBodySize' or 'engi.WithMaxBodySize', without them decoded body is limited by 32MB.
+//
+// Result can be retrieved from context using 'engi.Items[T]'.
+func NDJSON[T any](options ...request.Option) engi.Middleware {
+	return &ndjsonBody[T]{
+		options: append([]request.Option{validate.Struct}, options...),
+	}
+}
+
+// streamDocs - describes streamed body and responses for bodies that can't be read.
+func streamDocs(route *routes.Route, contentTypes []string, schema *docs.Schema) {
+	route.Operation.RequestBody = &docs.RequestBody{
+		Required: true,
+		Content:  make(map[string]*docs.MediaType, len(contentTypes)),
+	}
+
+	for _, contentType := range contentTypes {
+		route.Operation.RequestBody.Content[contentType] = &docs.MediaType{Schema: schema}
+	}
+
+	route.Operation.Responses[strconv.Itoa(http.StatusUnsupportedMediaType)] = &docs.Response{
+		Description: http.StatusText(http.StatusUnsupportedMediaType),
+	}
+}
+
+// bodyError - responds to errors of opening or reading body, other errors are returned as is.
+func bodyError(response *response.Response, err error) error {
+	switch {
+	case errors.Is(err, request.ErrBodyTooLarge):
+		return response.RequestEntityTooLarge(err.Error())
+	case errors.Is(err, request.ErrUnsupportedEncoding), errors.Is(err, request.ErrNotForm):
+		return response.UnsupportedMediaType(err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,289 @@
+package parameter_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/stretchr/testify/assert"
+)
+
+type event struct {
+	Level   string `json:"level" validate:"oneof=info error"`
+	Message string `json:"message"`
+}
+
+type ingestService struct{}
+
+func (s *ingestService) Prefix() string {
+	return "ingest"
+}
+
+func (s *ingestService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.PST("events"): engi.Handle(s.events,
+			parameter.MaxBodySize(256),
+			parameter.NDJSON[event](),
+		),
+		engi.PST("raw"): engi.Handle(s.raw,
+			parameter.StreamBody("text/plain"),
+		),
+	}
+}
+
+func (s *ingestService) events(ctx context.Context, req engi.Request, resp engi.Response) error {
+	var result []string
+
+	for item, err := range engi.Items[event](ctx, req) {
+		if errors.Is(err, engi.ErrBodyTooLarge) {
+			return resp.RequestEntityTooLarge(err.Error())
+		}
+
+		if err != nil {
+			result = append(result, err.Error())
+
+			continue
+		}
+
+		result = append(result, item.Message)
+	}
+
+	return resp.OK(result)
+}
+
+func (s *ingestService) raw(_ context.Context, req engi.Request, resp engi.Response) error {
+	size, err := io.Copy(io.Discard, req.Stream())
+	if errors.Is(err, engi.ErrBodyTooLarge) {
+		return resp.RequestEntityTooLarge(err.Error())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return resp.OK(size)
+}
+
+func compress(encoding, data string) io.Reader {
+	var buffer bytes.Buffer
+
+	switch encoding {
+	case "gzip":
+		var writer = gzip.NewWriter(&buffer)
+		_, _ = writer.Write([]byte(data))
+		_ = writer.Close()
+	case "zstd":
+		writer, _ := zstd.NewWriter(&buffer)
+		_, _ = writer.Write([]byte(data))
+		_ = writer.Close()
+	default:
+		buffer.WriteString(data)
+	}
+
+	return reader{&buffer}
+}
+
+// zstdFrame - returns zstd frame declaring window of 2^(10+exponent) bytes with data stored as raw block.
+func zstdFrame(exponent byte, data string) io.Reader {
+	var (
+		frame  = []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00, exponent << 3}
+		header = 1 | len(data)<<3
+	)
+
+	frame = append(frame, byte(header), byte(header>>8), byte(header>>16))
+
+	return reader{bytes.NewReader(append(frame, data...))}
+}
+
+func TestStreamBody(t *testing.T) {
+	var engine = engi.New("", engi.WithMaxBodySize(64))
+
+	assert.NoError(t, engine.RegisterServices(&ingestService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	const events = `{"level":"info","message":"a"}` + "\n" +
+		`{"level":"debug","message":"b"}` + "\n" +
+		`{"level":"error","message":"c"}` + "\n"
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		encoding    string
+		body        string
+		// frame - body already compressed with 'encoding'.
+		frame      io.Reader
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:        "items",
+			path:        "events",
+			contentType: "application/x-ndjson",
+			body:        events,
+			wantStatus:  http.StatusOK,
+			wantBody:    `["a","item 2: 'level' should be one of: info, error","c"]`,
+		},
+		{
+			name:        "gzip",
+			path:        "events",
+			contentType: "application/x-ndjson",
+			encoding:    "gzip",
+			body:        events,
+			wantStatus:  http.StatusOK,
+			wantBody:    `["a","item 2: 'level' should be one of: info, error","c"]`,
+		},
+		{
+			name:        "zstd",
+			path:        "events",
+			contentType: "application/jsonl",
+			encoding:    "zstd",
+			body:        events,
+			wantStatus:  http.StatusOK,
+			wantBody:    `["a","item 2: 'level' should be one of: info, error","c"]`,
+		},
+		{
+			name:        "zstd window",
+			path:        "events",
+			contentType: "application/x-ndjson",
+			encoding:    "zstd",
+			frame:       zstdFrame(13, `{"level":"info","message":"a"}`+"\n"),
+			wantStatus:  http.StatusOK,
+			wantBody:    `["a"]`,
+		},
+		{
+			name:        "zstd window too large",
+			path:        "events",
+			contentType: "application/x-ndjson",
+			encoding:    "zstd",
+			frame:       zstdFrame(16, `{"level":"info","message":"a"}`+"\n"),
+			wantStatus:  http.StatusOK,
+			wantBody:    `["item 1: window size exceeded"]`,
+		},
+		{
+			name:        "malformed",
+			path:        "events",
+			contentType: "application/x-ndjson",
+			body:        `{"level":"info","message":"a"}` + "\n{\n",
+			wantStatus:  http.StatusOK,
+			wantBody:    `["a","item 2: unexpected EOF"]`,
+		},
+		{
+			name:        "decoded too large",
+			path:        "events",
+			contentType: "application/x-ndjson",
+			encoding:    "gzip",
+			body:        strings.Repeat(`{"level":"info","message":"a"}`+"\n", 20),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantBody:    `"item 9: request body too large: limit is 256 bytes"`,
+		},
+		{
+			name:        "content type",
+			path:        "events",
+			contentType: "application/json",
+			body:        events,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantBody: `"unsupported media type: 'application/json', ` +
+				`supported: application/x-ndjson, application/jsonl, application/json-lines"`,
+		},
+		{
+			name:        "encoding",
+			path:        "events",
+			contentType: "application/x-ndjson",
+			encoding:    "br",
+			body:        events,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantBody:    `"unsupported content encoding: 'br', supported: gzip, zstd"`,
+		},
+		{
+			name:        "raw",
+			path:        "raw",
+			contentType: "text/plain",
+			body:        strings.Repeat("a", 64),
+			wantStatus:  http.StatusOK,
+			wantBody:    `64`,
+		},
+		{
+			name:        "raw decoded too large",
+			path:        "raw",
+			contentType: "text/plain",
+			encoding:    "gzip",
+			body:        strings.Repeat("a", 1000),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantBody:    `"request body too large: limit is 64 bytes"`,
+		},
+		{
+			name:        "raw too large",
+			path:        "raw",
+			contentType: "text/plain",
+			body:        strings.Repeat("a", 65),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantBody:    `"request body too large: limit is 64 bytes"`,
+		},
+	}
+
+	for _, tt := range tests {
+		var payload = tt.frame
+		if payload == nil {
+			payload = compress(tt.encoding, tt.body)
+		}
+
+		request, err := http.NewRequest(http.MethodPost, server.URL+"/ingest/"+tt.path, payload)
+		assert.NoError(t, err)
+
+		request.Header.Set("Content-Type", tt.contentType)
+
+		if tt.encoding != "" {
+			request.Header.Set("Content-Encoding", tt.encoding)
+		}
+
+		resp, err := http.DefaultClient.Do(request)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+		assert.Equal(t, tt.wantBody, string(body), tt.name)
+	}
+}
+
+func TestStreamBodyDecodedLimit(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&ingestService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	// Compressed body is small, but it's decoded only up to default limit.
+	request, err := http.NewRequest(http.MethodPost, server.URL+"/ingest/raw",
+		compress("gzip", strings.Repeat("a", 33<<20)),
+	)
+	assert.NoError(t, err)
+
+	request.Header.Set("Content-Type", "text/plain")
+	request.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Equal(t, `"request body too large: limit is 33554432 bytes"`, string(body))
+}
@@ -0,0 +1,63 @@
+package parameter_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter/header"
+	"github.com/kliuchnikovv/engi/definition/parameter/query"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type validationService struct{}
+
+func (s *validationService) Prefix() string {
+	return "validation"
+}
+
+func (s *validationService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET(""): engi.Handle(s.get,
+			query.Integer("limit", validate.AND(validate.Greater(10), validate.Less(5))),
+			query.String("name", validate.NotEmpty),
+			header.Integer("X-Page"),
+		),
+	}
+}
+
+func (s *validationService) get(_ context.Context, _ engi.Request, resp engi.Response) error {
+	return resp.NoContent()
+}
+
+func TestValidationErrorsAggregated(t *testing.T) {
+	var engine = engi.New("", engi.ResponseAsJSON(response.AsObject))
+
+	assert.NoError(t, engine.RegisterServices(&validationService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/validation/?limit=7")
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.JSONEq(t, `{
+		"error": "'limit' should be greater than 10; 'limit' should be less than 5; parameter not found: name; parameter not found: X-Page",
+		"errors": [
+			{"location": "query", "field": "limit", "rule": "gt", "message": "'limit' should be greater than 10"},
+			{"location": "query", "field": "limit", "rule": "lt", "message": "'limit' should be less than 5"},
+			{"location": "query", "field": "name", "rule": "required", "message": "parameter not found: name"},
+			{"location": "header", "field": "X-Page", "rule": "required", "message": "parameter not found: X-Page"}
+		]
+	}`, string(body))
+}
@@ -0,0 +1,116 @@
+package response_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"github.com/stretchr/testify/assert"
+)
+
+var modified = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+type pageService struct{}
+
+func (s *pageService) Prefix() string {
+	return "pages"
+}
+
+func (s *pageService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET("computed"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.OK(item{Name: "a"})
+			},
+			response.ETag(),
+			response.CacheControl("public", response.MaxAge(time.Hour)),
+		),
+		engi.GET("weak"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.OK(item{Name: "a"})
+			},
+			response.WeakETag(),
+		),
+		engi.GET("explicit"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.WithETag("v7").WithLastModified(modified.Add(time.Millisecond)).OK(item{Name: "a"})
+			},
+			response.CacheControl("no-cache"),
+		),
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&pageService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/pages/computed")
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	var computed = resp.Header.Get("ETag")
+
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, computed)
+	assert.Equal(t, "public, max-age=3600", resp.Header.Get("Cache-Control"))
+
+	tests := []struct {
+		name             string
+		path             string
+		header           map[string]string
+		wantStatus       int
+		wantETag         string
+		wantLastModified string
+	}{
+		{"computed", "computed", nil, http.StatusOK, computed, ""},
+		{"computed matched", "computed", map[string]string{"If-None-Match": `"x", ` + computed}, http.StatusNotModified, computed, ""},
+		{"computed weakly matched", "computed", map[string]string{"If-None-Match": "W/" + computed}, http.StatusNotModified, computed, ""},
+		{"computed any", "computed", map[string]string{"If-None-Match": "*"}, http.StatusNotModified, computed, ""},
+		{"computed changed", "computed", map[string]string{"If-None-Match": `"x"`}, http.StatusOK, computed, ""},
+		{"computed strong match failed", "weak", map[string]string{"If-Match": "W/" + computed}, http.StatusPreconditionFailed, "", ""},
+		{"weak", "weak", map[string]string{"If-None-Match": computed}, http.StatusNotModified, "W/" + computed, ""},
+		{"explicit", "explicit", map[string]string{"If-None-Match": `"v7"`}, http.StatusNotModified, `"v7"`, http.TimeFormat},
+		{"not modified since", "explicit", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, http.StatusNotModified, `"v7"`, http.TimeFormat},
+		{"modified since", "explicit", map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)}, http.StatusOK, `"v7"`, http.TimeFormat},
+		// 'If-None-Match' takes precedence over 'If-Modified-Since'.
+		{"precedence", "explicit", map[string]string{
+			"If-None-Match":     `"v6"`,
+			"If-Modified-Since": modified.Format(http.TimeFormat),
+		}, http.StatusOK, `"v7"`, http.TimeFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, server.URL+"/pages/"+tt.path, nil)
+			assert.NoError(t, err)
+
+			for key, value := range tt.header {
+				request.Header.Set(key, value)
+			}
+
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantETag, resp.Header.Get("ETag"))
+
+			if tt.wantLastModified != "" {
+				assert.Equal(t, modified.Format(http.TimeFormat), resp.Header.Get("Last-Modified"))
+			}
+
+			if tt.wantStatus == http.StatusNotModified {
+				assert.Empty(t, resp.Header.Get("Content-Type"))
+			}
+		})
+	}
+
+	assert.Panics(t, func() { response.CacheControl() })
+}
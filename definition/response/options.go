@@ -1,6 +1,12 @@
 package response
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/internal/response"
 	"github.com/kliuchnikovv/engi/internal/routes"
 	"github.com/kliuchnikovv/engi/internal/types"
 )
@@ -17,6 +23,26 @@ func MarshalAs(marshaler func() Marshaler) routes.Middleware {
 	}
 }
 
+// Offers - restricts types route's responses can be encoded with to 'contentTypes' (chosen by 'Accept' header),
+// codecs of types should be registered (see 'engi.WithCodecs').
+func Offers(contentTypes ...string) routes.Middleware {
+	return &offersObject{
+		contentTypes: contentTypes,
+	}
+}
+
+// Heartbeat - sets interval of heartbeat comments of route's event stream (see 'engi.HandleSSE'),
+// 'response.DefaultHeartbeat' is used by default, zero disables heartbeat.
+//
+// Panics if interval is negative.
+func Heartbeat(interval time.Duration) routes.Middleware {
+	if interval < 0 {
+		panic(fmt.Sprintf("invalid heartbeat interval: %s", interval))
+	}
+
+	return heartbeatObject(interval)
+}
+
 func AsIs() Responser {
 	return new(types.ResponseAsIs)
 }
@@ -32,3 +58,43 @@ func AsJSON() Marshaler {
 func AsXML() Marshaler {
 	return Marshaler(types.NewXMLMarshaler())
 }
+
+// AsCodec - returns marshaler encoding responses with codec, e.g. 'response.MarshalAs(msgpack.Marshaler)'.
+func AsCodec(c codec.Codec) Marshaler {
+	return Marshaler{
+		ContentType: c.ContentType,
+		Marshal:     c.Marshal,
+	}
+}
+
+// ETag - computes strong entity tag of route's responses from marshaled payload if handler didn't set one
+// (see 'Response.WithETag'), 'GET' and 'HEAD' requests with matching 'If-None-Match' are answered with 304.
+func ETag() routes.Middleware {
+	return etagObject(response.StrongETag)
+}
+
+// WeakETag - computes weak entity tag of route's responses from marshaled payload, see 'ETag'.
+func WeakETag() routes.Middleware {
+	return etagObject(response.WeakETag)
+}
+
+// CacheControl - sets caching directives of route's responses, e.g. 'CacheControl("public", MaxAge(time.Hour))'.
+//
+// Panics if no directives provided.
+func CacheControl(directives ...string) routes.Middleware {
+	if len(directives) == 0 {
+		panic("no cache directives provided")
+	}
+
+	return cacheControlObject(strings.Join(directives, ", "))
+}
+
+// MaxAge - returns 'max-age' directive, see 'CacheControl'.
+func MaxAge(age time.Duration) string {
+	return fmt.Sprintf("max-age=%d", int(age.Seconds()))
+}
+
+// SharedMaxAge - returns 's-maxage' directive applied by shared caches only, see 'CacheControl'.
+func SharedMaxAge(age time.Duration) string {
+	return fmt.Sprintf("s-maxage=%d", int(age.Seconds()))
+}
@@ -2,7 +2,13 @@ package response
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/response"
 	"github.com/kliuchnikovv/engi/internal/routes"
@@ -27,9 +33,8 @@ func (object *responserObject) Handle(ctx context.Context, _ *request.Request, r
 	return nil
 }
 
-func (object *responserObject) Docs(*routes.Route) {
-	panic("not implemented")
-}
+// Docs - responser changes body only at runtime, content types are documented by 'marshalerObject'.
+func (object *responserObject) Docs(*routes.Route) {}
 
 func (object *responserObject) Priority() int {
 	return 0
@@ -48,10 +53,108 @@ func (object *marshalerObject) Handle(ctx context.Context, _ *request.Request, r
 	return nil
 }
 
-func (object *marshalerObject) Docs(*routes.Route) {
-	panic("not implemented")
+func (object *marshalerObject) Docs(route *routes.Route) {
+	for _, response := range route.Operation.Responses {
+		response.Content = map[string]*docs.MediaType{
+			object.marshaler.ContentType(): {},
+		}
+	}
 }
 
 func (object *marshalerObject) Priority() int {
 	return 0
 }
+
+type offersObject struct {
+	contentTypes []string
+	offers       []codec.Codec
+}
+
+func (object *offersObject) Bind(route *routes.Route) error {
+	var codecs = route.Codecs
+	if codecs == nil {
+		codecs = codec.Default()
+	}
+
+	object.offers = object.offers[:0]
+
+	for _, contentType := range object.contentTypes {
+		offer, ok := codecs.Get(contentType)
+		if !ok {
+			return fmt.Errorf("offered type '%s': %w", contentType, codec.ErrUnsupportedMediaType)
+		}
+
+		object.offers = append(object.offers, offer)
+	}
+
+	return nil
+}
+
+func (object *offersObject) Handle(_ context.Context, _ *request.Request, resp *response.Response) error {
+	response.RestrictOffers(resp, object.offers)
+	return nil
+}
+
+func (object *offersObject) Docs(route *routes.Route) {
+	route.Operation.Responses[strconv.Itoa(http.StatusNotAcceptable)] = &docs.Response{
+		Description: http.StatusText(http.StatusNotAcceptable),
+	}
+
+	for _, response := range route.Operation.Responses {
+		response.Content = make(map[string]*docs.MediaType, len(object.contentTypes))
+
+		for _, contentType := range object.contentTypes {
+			response.Content[contentType] = &docs.MediaType{}
+		}
+	}
+}
+
+func (object *offersObject) Priority() int {
+	return 0
+}
+
+type heartbeatObject time.Duration
+
+func (interval heartbeatObject) Handle(_ context.Context, _ *request.Request, resp *response.Response) error {
+	response.SetHeartbeat(resp, time.Duration(interval))
+
+	return nil
+}
+
+func (interval heartbeatObject) Docs(*routes.Route) {}
+
+func (interval heartbeatObject) Priority() int {
+	return 0
+}
+
+type etagObject response.ETagMode
+
+func (mode etagObject) Handle(_ context.Context, _ *request.Request, resp *response.Response) error {
+	response.SetETagMode(resp, response.ETagMode(mode))
+
+	return nil
+}
+
+func (mode etagObject) Docs(route *routes.Route) {
+	route.Operation.Responses[strconv.Itoa(http.StatusNotModified)] = &docs.Response{
+		Description: http.StatusText(http.StatusNotModified),
+	}
+}
+
+func (mode etagObject) Priority() int {
+	return 0
+}
+
+type cacheControlObject string
+
+func (directives cacheControlObject) Handle(_ context.Context, _ *request.Request, resp *response.Response) error {
+	resp.ResponseWriter().Header().Set("Cache-Control", string(directives))
+
+	return nil
+}
+
+func (directives cacheControlObject) Docs(*routes.Route) {}
+
+func (directives cacheControlObject) Priority() int {
+	return 0
+}
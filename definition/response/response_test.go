@@ -0,0 +1,135 @@
+package response_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	Name string `json:"name" xml:"name"`
+}
+
+type itemService struct{}
+
+func (s *itemService) Prefix() string {
+	return "items"
+}
+
+func (s *itemService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET(""): engi.Handle(s.get),
+		engi.GET("json"): engi.Handle(s.get,
+			response.Offers("application/json"),
+		),
+		engi.GET("xml"): engi.Handle(s.get,
+			response.Offers("application/xml"),
+		),
+	}
+}
+
+func (s *itemService) get(_ context.Context, _ engi.Request, resp engi.Response) error {
+	return resp.OK(item{Name: "a"})
+}
+
+func TestNegotiation(t *testing.T) {
+	var engine = engi.New("", engi.ResponseAsJSON(response.AsObject))
+
+	assert.NoError(t, engine.RegisterServices(&itemService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name            string
+		path            string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantVary        string
+		wantBody        string
+	}{
+		{"default", "", "", http.StatusOK, "application/json", "Accept",
+			`{"result":{"name":"a"}}`},
+		{"xml", "", "application/json;q=0.5, application/xml", http.StatusOK, "application/xml", "Accept",
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><result><name>a</name></result></response>`},
+		{"text", "", "text/*", http.StatusOK, "text/plain", "Accept",
+			`{a}`},
+		{"not acceptable", "", "image/png", http.StatusNotAcceptable, "application/json", "Accept",
+			`{"error":"Not Acceptable, supported: application/json, application/xml, text/plain"}`},
+		{"restricted", "json", "application/xml", http.StatusNotAcceptable, "application/json", "",
+			`{"error":"Not Acceptable, supported: application/json"}`},
+		{"restricted wildcard", "json", "application/xml, */*;q=0.1", http.StatusOK, "application/json", "",
+			`{"result":{"name":"a"}}`},
+		// Type of engine's marshaler isn't offered if route restricts offers.
+		{"restricted default", "xml", "", http.StatusOK, "application/xml", "",
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><result><name>a</name></result></response>`},
+		{"restricted marshaler", "xml", "application/json", http.StatusNotAcceptable, "application/json", "",
+			`{"error":"Not Acceptable, supported: application/xml"}`},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/items/"+tt.path, nil)
+		assert.NoError(t, err)
+
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+		assert.Equal(t, tt.wantContentType, resp.Header.Get("Content-Type"), tt.name)
+		assert.Equal(t, tt.wantVary, resp.Header.Get("Vary"), tt.name)
+		assert.Equal(t, tt.wantBody, string(body), tt.name)
+	}
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]json.RawMessage `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	var responses = document.Paths["/items/json"]["get"].Responses
+	assert.Contains(t, responses, "406")
+	assert.Contains(t, responses["default"].Content, "application/json")
+	assert.Len(t, responses["default"].Content, 1)
+}
+
+type badOfferService struct{}
+
+func (s *badOfferService) Prefix() string {
+	return "bad"
+}
+
+func (s *badOfferService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET(""): engi.Handle(
+			func(context.Context, engi.Request, engi.Response) error { return nil },
+			response.Offers("application/yaml"),
+		),
+	}
+}
+
+func TestOffersUnknownType(t *testing.T) {
+	assert.ErrorContains(t, engi.New("").RegisterServices(&badOfferService{}), "offered type 'application/yaml'")
+}
@@ -0,0 +1,144 @@
+package response_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/middlewares/auth"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type feedService struct{}
+
+func (s *feedService) Prefix() string {
+	return "feed"
+}
+
+func (s *feedService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET("events"): engi.HandleSSE(s.events,
+			auth.Basic("user", "secret"),
+			response.Heartbeat(50*time.Millisecond),
+		),
+	}
+}
+
+func (s *feedService) events(ctx context.Context, _ engi.Request, events engi.SSEWriter) error {
+	var next = 1
+
+	if id, err := strconv.Atoi(events.LastEventID()); err == nil {
+		next = id + 1
+	}
+
+	if err := events.Send(engi.Event{Event: "hello", Data: "line 1\nline 2", Retry: time.Second}); err != nil {
+		return err
+	}
+
+	// Events are sent after server's write timeout expired.
+	for id := next; id < next+2; id++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(150 * time.Millisecond):
+		}
+
+		if err := events.Send(engi.Event{ID: strconv.Itoa(id), Data: item{Name: "a"}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestSSE(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&feedService{}))
+
+	var server = httptest.NewUnstartedServer(engine.Handler())
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/feed/events", nil)
+	assert.NoError(t, err)
+
+	// Middlewares reject request before stream is opened.
+	resp, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.NotEqual(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	request.SetBasicAuth("user", "secret")
+	request.Header.Set("Last-Event-ID", "41")
+
+	resp, err = http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	var (
+		scanner  = bufio.NewScanner(resp.Body)
+		messages []string
+		message  []string
+		comments int
+	)
+
+	for scanner.Scan() {
+		switch line := scanner.Text(); {
+		case line == ": heartbeat":
+			comments++
+		case line != "":
+			message = append(message, line)
+		case len(message) > 0:
+			messages = append(messages, strings.Join(message, "\n"))
+			message = nil
+		}
+	}
+
+	assert.NoError(t, scanner.Err())
+	assert.Equal(t, []string{
+		"event: hello\nretry: 1000\ndata: line 1\ndata: line 2",
+		"id: 42\ndata: {\"name\":\"a\"}",
+		"id: 43\ndata: {\"name\":\"a\"}",
+	}, messages)
+	assert.Greater(t, comments, 0)
+}
+
+func TestSSEDocs(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&feedService{}))
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]json.RawMessage `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+	assert.Contains(t, document.Paths["/feed/events"]["get"].Responses["200"].Content, "text/event-stream")
+
+	assert.Panics(t, func() { response.Heartbeat(-time.Second) })
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/kliuchnikovv/engi/internal/response"
+)
+
+var ErrNilItems = errors.New("nil items can't be streamed")
+
+// NDJSON - responses with 200 code and newline-delimited JSON of items, e.g. 'response.NDJSON(resp, slices.Values(items))'.
+// Items are written as they're produced, streaming stops on cancellation of request's context.
+func NDJSON[T any](resp response.Responser, items iter.Seq[T]) error {
+	if items == nil {
+		return ErrNilItems
+	}
+
+	return NDJSON2(resp, func(yield func(T, error) bool) {
+		for item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	})
+}
+
+// NDJSON2 - same as 'NDJSON' for items produced with errors, e.g. rows of database query.
+// Streaming stops on first error, it's returned after items written before it were sent.
+func NDJSON2[T any](resp response.Responser, items iter.Seq2[T, error]) error {
+	if items == nil {
+		return ErrNilItems
+	}
+
+	return resp.Stream("application/x-ndjson", func(w io.Writer) error {
+		var encoder = json.NewEncoder(w)
+
+		for item, err := range items {
+			if err != nil {
+				return err
+			}
+
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
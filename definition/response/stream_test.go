@@ -0,0 +1,205 @@
+package response_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type exportService struct {
+	// next - lets stream write its next line.
+	next chan struct{}
+	// failures - errors stream got after client went away.
+	failures chan error
+}
+
+func (s *exportService) Prefix() string {
+	return "export"
+}
+
+func (s *exportService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET("stream"): engi.Handle(s.stream),
+		engi.GET("reader"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.Reader(io.NopCloser(strings.NewReader("<html><body>report</body></html>")))
+			},
+		),
+		engi.GET("items"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return response.NDJSON(resp, slices.Values([]item{{Name: "a"}, {Name: "b"}}))
+			},
+		),
+		engi.GET("failing"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return response.NDJSON2(resp, iter.Seq2[item, error](func(yield func(item, error) bool) {
+					if yield(item{Name: "a"}, nil) {
+						yield(item{}, errors.New("database is gone"))
+					}
+				}))
+			},
+		),
+		engi.GET("slow"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return response.NDJSON(resp, iter.Seq[item](func(yield func(item) bool) {
+					for i := 0; i < 5; i++ {
+						time.Sleep(60 * time.Millisecond)
+
+						if !yield(item{Name: "a"}) {
+							return
+						}
+					}
+				}))
+			},
+		),
+		engi.GET("invalid"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				var items iter.Seq[item]
+
+				if err := response.NDJSON(resp, items); err != nil {
+					return resp.InternalServerError(err.Error())
+				}
+
+				return nil
+			},
+		),
+	}
+}
+
+func (s *exportService) stream(_ context.Context, _ engi.Request, resp engi.Response) error {
+	return resp.Stream("text/plain", func(w io.Writer) error {
+		for {
+			if _, err := io.WriteString(w, "line\n"); err != nil {
+				s.failures <- err
+
+				return err
+			}
+
+			<-s.next
+		}
+	})
+}
+
+func TestStream(t *testing.T) {
+	var (
+		service = &exportService{
+			next:     make(chan struct{}),
+			failures: make(chan error, 1),
+		}
+		engine = engi.New("")
+	)
+
+	assert.NoError(t, engine.RegisterServices(service))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/export/stream", nil)
+	assert.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "text/plain", resp.Header.Get("Content-Type"))
+
+	// Lines are flushed while handler is still writing.
+	var reader = bufio.NewReader(resp.Body)
+
+	for i := 0; i < 3; i++ {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, "line\n", line)
+
+		service.next <- struct{}{}
+	}
+
+	// Writing stops when client goes away: request's context is cancelled or connection is broken.
+	cancel()
+	resp.Body.Close()
+
+	for {
+		select {
+		case err := <-service.failures:
+			assert.Error(t, err)
+
+			return
+		case service.next <- struct{}{}:
+		case <-time.After(time.Second):
+			t.Fatal("stream wasn't stopped")
+		}
+	}
+}
+
+func TestStreamPayloads(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&exportService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		path            string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{"reader", http.StatusOK, "text/html; charset=utf-8", "<html><body>report</body></html>"},
+		{"items", http.StatusOK, "application/x-ndjson", `{"name":"a"}` + "\n" + `{"name":"b"}` + "\n"},
+		{"failing", http.StatusOK, "application/x-ndjson", `{"name":"a"}` + "\n"},
+		{"invalid", http.StatusInternalServerError, "application/json",
+			`"nil items can't be streamed"`},
+	}
+
+	for _, tt := range tests {
+		resp, err := http.Get(server.URL + "/export/" + tt.path)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.path)
+		assert.Equal(t, tt.wantContentType, resp.Header.Get("Content-Type"), tt.path)
+		assert.Equal(t, tt.wantBody, string(body), tt.path)
+	}
+}
+
+func TestStreamWriteTimeout(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&exportService{}))
+
+	var server = httptest.NewUnstartedServer(engine.Handler())
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/export/slow")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	defer resp.Body.Close()
+
+	// Stream lasts longer than server's write timeout.
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat(`{"name":"a"}`+"\n", 5), string(body))
+}
@@ -2,138 +2,160 @@ package validate
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
 	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/types"
 )
 
 // NotEmpty - checks if parameter is not empty by it's type.
 // NOTE: boolean parameter will be ignored.
-func NotEmpty(p *request.Parameter) error {
-	var isNotEmpty func() bool
-
-	switch typed := p.Parsed.(type) {
-	case bool:
-		// Bool can't be empty
-		return nil
-	case int64:
-		isNotEmpty = func() bool { return typed != 0 }
-	case float64:
-		isNotEmpty = func() bool { return typed != 0 }
-	case string:
-		isNotEmpty = func() bool { return len(typed) != 0 }
-	case time.Time:
-		isNotEmpty = func() bool { return typed.UnixNano() != 0 }
-	default:
-		isNotEmpty = func() bool { return typed != nil }
-	}
-
-	if isNotEmpty() {
-		return nil
-	}
-
-	return fmt.Errorf("'%s' shouldn't be empty", p.Name)
-}
+var NotEmpty = newRule("not_empty", "'{field}' shouldn't be empty", nil,
+	anyType,
+	func(value any) bool {
+		var v = reflect.ValueOf(value)
+
+		switch v.Kind() {
+		case reflect.Bool:
+			// Bool can't be empty
+			return true
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+			return v.Len() != 0
+		default:
+			return !v.IsZero()
+		}
+	},
+)
 
 // Greater - checks if parameter greater than a number.
 // NOTES:
-//   - for 'int' and 'float' parameters - simple values comparison;
+//   - for numeric parameters - simple values comparison;
 //   - for 'string' - comparing with it's length;
 //   - for 'time' - comparing with time.Unix() value in seconds;
-func Greater(than float64) request.Option {
-	return func(p *request.Parameter) error {
-		var greater func() bool
-
-		switch typed := p.Parsed.(type) {
-		case int64:
-			greater = func() bool { return typed > int64(than) }
-		case float64:
-			greater = func() bool { return typed > than }
-		case string:
-			greater = func() bool { return len(typed) > int(than) }
-		case time.Time:
-			greater = func() bool { return typed.Unix() > int64(than) }
-		}
-
-		if greater() {
-			return nil
-		}
-
-		return fmt.Errorf("'%s' should be greater than %f", p.Name, than)
-	}
+//
+// Message arguments: '{than}'.
+func Greater(than float64) *Rule {
+	return newRule("gt", "'{field}' should be greater than {than}", map[string]any{"than": than},
+		isOrdered,
+		func(value any) bool {
+			return compare(ordered(value), than, func(cmp int) bool { return cmp > 0 })
+		},
+	)
 }
 
 // Less - checks if parameter less than a number.
 // NOTES:
-//   - for 'int' and 'float' parameters - simple values comparison;
+//   - for numeric parameters - simple values comparison;
 //   - for 'string' - comparing with it's length;
 //   - for 'time' - comparing with time.Unix() value in seconds;
-func Less(than float64) request.Option {
-	return func(p *request.Parameter) error {
-		var greater func() bool
-
-		switch typed := p.Parsed.(type) {
-		case int64:
-			greater = func() bool { return typed < int64(than) }
-		case float64:
-			greater = func() bool { return typed < than }
-		case string:
-			greater = func() bool { return len(typed) < int(than) }
-		case time.Time:
-			greater = func() bool { return typed.UnixNano() < int64(than) }
-		}
+//
+// Message arguments: '{than}'.
+func Less(than float64) *Rule {
+	return newRule("lt", "'{field}' should be less than {than}", map[string]any{"than": than},
+		isOrdered,
+		func(value any) bool {
+			return compare(ordered(value), than, func(cmp int) bool { return cmp < 0 })
+		},
+	)
+}
 
-		if greater() {
-			return nil
-		}
+// isOrdered - types supported by 'Greater' and 'Less'.
+func isOrdered(typ reflect.Type) bool {
+	return isNumber(typ) || isTime(typ) || typ.Kind() == reflect.String
+}
+
+// ordered - returns number 'Greater' and 'Less' compare with.
+func ordered(value any) any {
+	if _, ok := value.(request.Decimal); ok {
+		return value
+	}
+
+	if typed, ok := value.(time.Time); ok {
+		return typed.Unix()
+	}
 
-		return fmt.Errorf("'%s' should be less than %f", p.Name, than)
+	if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+		return v.Len()
 	}
+
+	return value
 }
 
-// OR - combines several parameter checks and passes if one of them successful.
-func OR(opts ...request.Option) request.Option {
-	return func(p *request.Parameter) error {
-		var (
-			passed bool
-			errs   []string
-		)
-
-		for _, option := range opts {
-			if err := option(p); err != nil {
-				errs = append(errs, err.Error())
-				continue
-			}
+// NOT - negates check: passes if option failed.
+//
+// Message arguments: '{check}' - name of negated rule.
+func NOT(option request.Option) *Rule {
+	var (
+		name     = "check"
+		supports = anyType
+	)
+
+	if rule, ok := option.(*Rule); ok {
+		name = rule.name
+		supports = rule.supports
+	}
 
-			passed = true
+	return newRule("not", "'{field}' shouldn't pass '{check}' check", map[string]any{"check": name},
+		supports,
+		func(value any) bool {
+			return option.Validate(&request.Parameter{Parsed: value}) != nil
+		},
+	)
+}
 
-			break
-		}
+// group - validator combining several options, can validate types all of them can.
+type group struct {
+	request.Validator
 
-		if passed {
-			return nil
-		}
+	options []request.Option
+}
 
-		return fmt.Errorf("'%s' failed check: %s", p.Name, strings.Join(errs, " and "))
+func (g group) Check(typ reflect.Type) error {
+	return request.Check(typ, g.options)
+}
+
+// OR - combines several parameter checks and passes if one of them successful.
+func OR(opts ...request.Option) request.Option {
+	return group{
+		options: opts,
+		Validator: request.Rule("or", func(p *request.Parameter) error {
+			var errs []string
+
+			for _, option := range opts {
+				if err := option.Validate(p); err != nil {
+					errs = append(errs, err.Error())
+					continue
+				}
+
+				return nil
+			}
+
+			return fmt.Errorf("'%s' failed check: %s", p.Name, strings.Join(errs, " and "))
+		}),
 	}
 }
 
 // AND - combines several parameter checks and failing if one of them failed.
+// All checks are run, so every failure is reported.
 func AND(opts ...request.Option) request.Option {
-	return func(p *request.Parameter) error {
-		var err error
-
-		for _, option := range opts {
-			if err = option(p); err != nil {
-				break
+	return group{
+		options: opts,
+		Validator: func(p *request.Parameter) error {
+			var failures types.ValidationErrors
+
+			for _, option := range opts {
+				if err := option.Validate(p); err != nil {
+					failures = append(failures, request.Failures(err, "", "", "and")...)
+				}
 			}
-		}
 
-		if err == nil {
-			return nil
-		}
+			if len(failures) == 0 {
+				return nil
+			}
 
-		return fmt.Errorf("'%s' failed check: %s", p.Name, err)
+			return failures
+		},
 	}
 }
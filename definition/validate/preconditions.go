@@ -0,0 +1,76 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/internal/docs"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+// Validators - returns entity tag and modification time of resource's current state,
+// both are empty if resource doesn't exist.
+type Validators func(ctx context.Context, request engi.Request) (etag string, lastModified time.Time, err error)
+
+// preconditions - middleware checking conditional headers of changing requests.
+type preconditions struct {
+	current Validators
+}
+
+// Preconditions - answers 'PUT', 'PATCH' and 'DELETE' requests with 412 before handler is called
+// if 'If-Match' or 'If-Unmodified-Since' don't match current state of resource (or 'If-None-Match' does),
+// so concurrent changes don't overwrite each other:
+//
+//	validate.Preconditions(func(ctx context.Context, r engi.Request) (string, time.Time, error) {
+//		article, err := articles.Get(ctx, r.Integer("id", placing.InPath))
+//		if err != nil {
+//			return "", time.Time{}, err
+//		}
+//
+//		return strconv.Itoa(article.Version), article.UpdatedAt, nil
+//	})
+//
+// Entity tags are compared as set by 'Response.WithETag', so handlers of 'GET' should use same ones.
+// Runs after parameters are extracted and isn't called if any of them is invalid,
+// errors of 'current' are responded with 500 without their details and returned to be logged.
+func Preconditions(current Validators) engi.Middleware {
+	return &preconditions{current: current}
+}
+
+func (p *preconditions) Handle(ctx context.Context, r *request.Request, resp *response.Response) error {
+	switch r.GetRequest().Method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil
+	}
+
+	etag, lastModified, err := p.current(ctx, r)
+	if err != nil {
+		if respErr := resp.InternalServerError(http.StatusText(http.StatusInternalServerError)); respErr != nil {
+			return respErr
+		}
+
+		return fmt.Errorf("preconditions: %w", err)
+	}
+
+	return response.Precondition(resp, etag, lastModified)
+}
+
+// DependsOnParameters - 'current' gets zero values of parameters which failed validation.
+func (p *preconditions) DependsOnParameters() {}
+
+func (p *preconditions) Docs(route *routes.Route) {
+	route.Operation.Responses[strconv.Itoa(http.StatusPreconditionFailed)] = &docs.Response{
+		Description: http.StatusText(http.StatusPreconditionFailed),
+	}
+}
+
+func (p *preconditions) Priority() int {
+	return 120
+}
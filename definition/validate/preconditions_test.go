@@ -0,0 +1,185 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/parameter/query"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type article struct {
+	Title string `json:"title"`
+}
+
+type articleService struct {
+	mutex     sync.Mutex
+	version   int
+	updatedAt time.Time
+}
+
+func (s *articleService) Prefix() string {
+	return "articles"
+}
+
+func (s *articleService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET("current"): engi.Handle(s.get),
+		engi.PUT("current"): engi.Handle(s.update,
+			parameter.Body(new(article)),
+			validate.Preconditions(s.current),
+		),
+		engi.PTC("current"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.NoContent()
+			},
+			query.Integer("version", validate.Greater(0)),
+			validate.Preconditions(s.broken),
+		),
+		engi.DEL("current"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error {
+				return resp.NoContent()
+			},
+			validate.Preconditions(s.current),
+		),
+	}
+}
+
+func (s *articleService) current(context.Context, engi.Request) (string, time.Time, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return strconv.Itoa(s.version), s.updatedAt, nil
+}
+
+func (s *articleService) broken(context.Context, engi.Request) (string, time.Time, error) {
+	return "", time.Time{}, errors.New("database is gone")
+}
+
+func (s *articleService) get(_ context.Context, _ engi.Request, resp engi.Response) error {
+	etag, updatedAt, _ := s.current(context.Background(), nil)
+
+	return resp.WithETag(etag).WithLastModified(updatedAt).OK(article{Title: "a"})
+}
+
+func (s *articleService) update(_ context.Context, _ engi.Request, resp engi.Response) error {
+	s.mutex.Lock()
+	s.version++
+	s.updatedAt = s.updatedAt.Add(time.Minute)
+	s.mutex.Unlock()
+
+	etag, updatedAt, _ := s.current(context.Background(), nil)
+
+	return resp.WithETag(etag).WithLastModified(updatedAt).OK(article{Title: "b"})
+}
+
+func TestPreconditions(t *testing.T) {
+	var (
+		service = &articleService{version: 1, updatedAt: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)}
+		engine  = engi.New("")
+	)
+
+	assert.NoError(t, engine.RegisterServices(service))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/articles/current")
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	var (
+		etag         = resp.Header.Get("ETag")
+		lastModified = resp.Header.Get("Last-Modified")
+	)
+
+	assert.Equal(t, `"1"`, etag)
+
+	tests := []struct {
+		name       string
+		method     string
+		header     map[string]string
+		wantStatus int
+		wantETag   string
+	}{
+		{"updated", http.MethodPut, map[string]string{"If-Match": etag}, http.StatusOK, `"2"`},
+		// Client's copy is outdated after update.
+		{"lost update", http.MethodPut, map[string]string{"If-Match": etag}, http.StatusPreconditionFailed, ""},
+		{"weak tag", http.MethodPut, map[string]string{"If-Match": `W/"2"`}, http.StatusPreconditionFailed, ""},
+		{"unmodified since", http.MethodPut, map[string]string{"If-Unmodified-Since": lastModified}, http.StatusPreconditionFailed, ""},
+		{"exists", http.MethodPut, map[string]string{"If-None-Match": "*"}, http.StatusPreconditionFailed, ""},
+		{"unconditional", http.MethodPut, nil, http.StatusOK, `"3"`},
+		{"delete outdated", http.MethodDelete, map[string]string{"If-Match": `"2"`}, http.StatusPreconditionFailed, ""},
+		{"delete", http.MethodDelete, map[string]string{"If-Match": `"x", "3"`}, http.StatusNoContent, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(tt.method, server.URL+"/articles/current", strings.NewReader(`{"title":"b"}`))
+			assert.NoError(t, err)
+
+			request.Header.Set("Content-Type", "application/json")
+
+			for key, value := range tt.header {
+				request.Header.Set(key, value)
+			}
+
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantETag, resp.Header.Get("ETag"))
+		})
+	}
+}
+
+func TestPreconditionsFailures(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&articleService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		// Resource's state isn't requested for invalid parameters.
+		{"invalid parameters", "", http.StatusBadRequest, "parameter not found: version"},
+		// Details of failure aren't sent to client.
+		{"failed", "?version=1", http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodPatch, server.URL+"/articles/current"+tt.query, nil)
+			assert.NoError(t, err)
+
+			resp, err := http.DefaultClient.Do(request)
+			assert.NoError(t, err)
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Contains(t, string(body), tt.wantBody)
+			assert.NotContains(t, string(body), "database")
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"context"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+)
+
+// requestValidator - middleware validating request as a whole.
+type requestValidator struct {
+	validator func(engi.Request) error
+}
+
+// Request - validates several parameters together, e.g. 'from' is before 'to' or path's 'id' equals body's one:
+//
+//	validate.Request(func(r engi.Request) error {
+//		if r.Time("from", time.RFC3339, placing.InQuery).After(r.Time("to", time.RFC3339, placing.InQuery)) {
+//			return errors.New("'from' should be before 'to'")
+//		}
+//
+//		return nil
+//	})
+//
+// Runs after all parameters and body are extracted and only if all of them are valid,
+// otherwise their failures are responded without calling validator.
+//
+// Validator can return 'engi.ValidationErrors' (or single '*engi.ValidationError') to point to fields,
+// other errors are reported with location 'request' and rule 'custom'.
+func Request(validator func(engi.Request) error) engi.Middleware {
+	return &requestValidator{validator: validator}
+}
+
+func (v *requestValidator) Handle(_ context.Context, r *request.Request, _ *response.Response) error {
+	if err := v.validator(r); err != nil {
+		return request.Failures(err, request.LocationRequest, "", "custom")
+	}
+
+	return nil
+}
+
+// DependsOnParameters - validator reads parameters, which are empty if they failed validation.
+func (v *requestValidator) DependsOnParameters() {}
+
+func (v *requestValidator) Docs(*routes.Route) {}
+
+func (v *requestValidator) Priority() int {
+	return 110
+}
@@ -0,0 +1,150 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/parameter/path"
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/definition/parameter/query"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type report struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title" validate:"required"`
+}
+
+type reportService struct{}
+
+func (s *reportService) Prefix() string {
+	return "reports"
+}
+
+func (s *reportService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.PUT(":id"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error { return resp.NoContent() },
+			validate.Request(func(r engi.Request) error {
+				var failures engi.ValidationErrors
+
+				if r.Time("from", time.DateOnly, placing.InQuery).After(r.Time("to", time.DateOnly, placing.InQuery)) {
+					failures = append(failures, engi.ValidationError{Field: "from", Message: "'from' should be before 'to'"})
+				}
+
+				if r.Integer("id", placing.InPath) != r.Body().(*report).ID {
+					failures = append(failures, engi.ValidationError{Field: "id", Rule: "equal", Message: "'id' should equal body's 'id'"})
+				}
+
+				if len(failures) > 0 {
+					return failures
+				}
+
+				return nil
+			}),
+			path.Integer("id"),
+			query.Time("from", time.DateOnly),
+			query.Time("to", time.DateOnly),
+			parameter.Body(new(report)),
+		),
+		engi.DEL(":id"): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error { return resp.NoContent() },
+			validate.Request(func(r engi.Request) error {
+				if r.Integer("id", placing.InPath) == 1 {
+					return errors.New("report 1 can't be deleted")
+				}
+
+				return nil
+			}),
+			path.Integer("id"),
+		),
+	}
+}
+
+func TestRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "valid",
+			method:     http.MethodPut,
+			url:        "/reports/1?from=2024-01-01&to=2024-02-01",
+			body:       `{"id":1,"title":"a"}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid request",
+			method:     http.MethodPut,
+			url:        "/reports/2?from=2024-03-01&to=2024-02-01",
+			body:       `{"id":1,"title":"a"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `[
+				{"location":"request","field":"from","rule":"custom","message":"'from' should be before 'to'"},
+				{"location":"request","field":"id","rule":"equal","message":"'id' should equal body's 'id'"}
+			]`,
+		},
+		// Validator isn't called for invalid parameters.
+		{
+			name:       "invalid parameters",
+			method:     http.MethodPut,
+			url:        "/reports/2?from=2024-03-01&to=2024-02-01",
+			body:       `{"id":1}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `[{"location":"body","field":"title","rule":"required","message":"'title' is required"}]`,
+		},
+		{
+			name:       "malformed body",
+			method:     http.MethodPut,
+			url:        "/reports/1?from=2024-01-01&to=2024-02-01",
+			body:       `{"id":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "plain error",
+			method:     http.MethodDelete,
+			url:        "/reports/1",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `[{"location":"request","rule":"custom","message":"report 1 can't be deleted"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		var engine = engi.New("")
+
+		assert.NoError(t, engine.RegisterServices(&reportService{}))
+
+		var server = httptest.NewServer(engine.Handler())
+
+		req, err := http.NewRequest(tt.method, server.URL+tt.url, strings.NewReader(tt.body))
+		assert.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		server.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+
+		if tt.wantBody != "" {
+			assert.JSONEq(t, tt.wantBody, string(body), tt.name)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/types"
+)
+
+var ErrUnsupportedType = errors.New("unsupported type")
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	decimalType  = reflect.TypeOf(request.Decimal(""))
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// Rule - named validation rule.
+//
+// Failure message can be changed using 'Message' method.
+type Rule struct {
+	name     string
+	args     map[string]any
+	message  string
+	supports func(reflect.Type) bool
+	check    func(value any) bool
+}
+
+func newRule(
+	name, message string,
+	args map[string]any,
+	supports func(reflect.Type) bool,
+	check func(value any) bool,
+) *Rule {
+	return &Rule{
+		name:     name,
+		args:     args,
+		message:  message,
+		supports: supports,
+		check:    check,
+	}
+}
+
+// Message - returns copy of rule with custom failure message.
+// Template can contain '{field}', '{value}', '{rule}' placeholders and rule's arguments (e.g. '{min}'):
+//
+//	validate.Min(1).Message("{field} must be at least {min}, got {value}")
+func (rule *Rule) Message(template string) *Rule {
+	var result = *rule
+
+	result.message = template
+
+	return &result
+}
+
+// Name - returns rule's name used in validation errors.
+func (rule *Rule) Name() string {
+	return rule.name
+}
+
+func (rule *Rule) Validate(p *request.Parameter) error {
+	var value = indirect(p.Parsed)
+
+	if value == nil {
+		return rule.fail(p, value)
+	}
+
+	if typ := reflect.TypeOf(value); !rule.supports(typ) {
+		return &types.ValidationError{
+			Rule:    rule.name,
+			Message: fmt.Sprintf("'%s': %s", p.Name, rule.unsupported(typ)),
+		}
+	}
+
+	if rule.check(value) {
+		return nil
+	}
+
+	return rule.fail(p, value)
+}
+
+// Check - checks that rule can validate values of type.
+func (rule *Rule) Check(typ reflect.Type) error {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == nil || rule.supports(typ) {
+		return nil
+	}
+
+	return rule.unsupported(typ)
+}
+
+func (rule *Rule) unsupported(typ reflect.Type) error {
+	return fmt.Errorf("%w: rule '%s' can't validate %s", ErrUnsupportedType, rule.name, typ)
+}
+
+func (rule *Rule) fail(p *request.Parameter, value any) error {
+	var replacements = []string{
+		"{field}", p.Name,
+		"{value}", fmt.Sprint(value),
+		"{rule}", rule.name,
+	}
+
+	for name, arg := range rule.args {
+		if number, ok := arg.(float64); ok {
+			arg = strconv.FormatFloat(number, 'f', -1, 64)
+		}
+
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(arg))
+	}
+
+	return &types.ValidationError{
+		Rule:    rule.name,
+		Message: strings.NewReplacer(replacements...).Replace(rule.message),
+	}
+}
+
+// indirect - returns value pointer points to, nil for nil pointer.
+func indirect(value any) any {
+	var v = reflect.ValueOf(value)
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+func anyType(reflect.Type) bool {
+	return true
+}
+
+// isNumber - integers, floating point numbers, durations and decimals.
+func isNumber(typ reflect.Type) bool {
+	if typ == decimalType {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// number - returns exact value of number, nil if it isn't a number (e.g. NaN).
+func number(value any) *big.Rat {
+	if decimal, ok := value.(request.Decimal); ok {
+		return decimal.Rat()
+	}
+
+	var v = reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return new(big.Rat).SetFloat64(v.Float())
+	default:
+		return nil
+	}
+}
+
+// compare - compares number with 'than', returns false if value isn't a number.
+func compare(value any, than float64, ok func(int) bool) bool {
+	var (
+		n     = number(value)
+		bound = new(big.Rat).SetFloat64(than)
+	)
+
+	if n == nil || bound == nil {
+		return false
+	}
+
+	return ok(n.Cmp(bound))
+}
+
+// hasLength - strings, slices, arrays and maps.
+func hasLength(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// length - returns number of characters in string or number of elements.
+func length(value any) int {
+	var v = reflect.ValueOf(value)
+
+	if v.Kind() == reflect.String {
+		return len([]rune(v.String()))
+	}
+
+	return v.Len()
+}
+
+// isText - strings and types implementing 'fmt.Stringer'.
+func isText(typ reflect.Type) bool {
+	return typ.Kind() == reflect.String ||
+		typ.Implements(stringerType) ||
+		reflect.PointerTo(typ).Implements(stringerType)
+}
+
+// text - returns textual representation of value.
+func text(value any) string {
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	var v = reflect.ValueOf(value)
+
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+
+	// Types with 'String' method on pointer receiver.
+	var pointer = reflect.New(v.Type())
+
+	pointer.Elem().Set(v)
+
+	if stringer, ok := pointer.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	return fmt.Sprint(value)
+}
+
+func isTime(typ reflect.Type) bool {
+	return typ == timeType
+}
@@ -0,0 +1,265 @@
+package validate
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+)
+
+// Min - checks if numeric parameter is greater than or equal to 'min'.
+//
+// Message arguments: '{min}'.
+func Min(min float64) *Rule {
+	return newRule("min", "'{field}' should be at least {min}", map[string]any{"min": min},
+		isNumber,
+		func(value any) bool {
+			return compare(value, min, func(cmp int) bool { return cmp >= 0 })
+		},
+	)
+}
+
+// Max - checks if numeric parameter is less than or equal to 'max'.
+//
+// Message arguments: '{max}'.
+func Max(max float64) *Rule {
+	return newRule("max", "'{field}' should be at most {max}", map[string]any{"max": max},
+		isNumber,
+		func(value any) bool {
+			return compare(value, max, func(cmp int) bool { return cmp <= 0 })
+		},
+	)
+}
+
+// Between - checks if numeric parameter is in range ['min', 'max'].
+//
+// Message arguments: '{min}', '{max}'.
+func Between(min, max float64) *Rule {
+	if min > max {
+		panic(fmt.Sprintf("invalid range: %v > %v", min, max))
+	}
+
+	return newRule("between", "'{field}' should be between {min} and {max}",
+		map[string]any{"min": min, "max": max},
+		isNumber,
+		func(value any) bool {
+			return compare(value, min, func(cmp int) bool { return cmp >= 0 }) &&
+				compare(value, max, func(cmp int) bool { return cmp <= 0 })
+		},
+	)
+}
+
+// MultipleOf - checks if numeric parameter is multiple of 'n'.
+//
+// Message arguments: '{n}'.
+func MultipleOf(n float64) *Rule {
+	var divisor = new(big.Rat).SetFloat64(n)
+	if divisor == nil || divisor.Sign() == 0 {
+		panic(fmt.Sprintf("invalid divisor: %v", n))
+	}
+
+	return newRule("multiple_of", "'{field}' should be multiple of {n}", map[string]any{"n": n},
+		isNumber,
+		func(value any) bool {
+			var number = number(value)
+			if number == nil {
+				return false
+			}
+
+			return number.Quo(number, divisor).IsInt()
+		},
+	)
+}
+
+// Len - checks if string has exactly 'n' characters or slice has exactly 'n' elements.
+//
+// Message arguments: '{len}'.
+func Len(n int) *Rule {
+	return newRule("len", "'{field}' should have length {len}", map[string]any{"len": n},
+		hasLength,
+		func(value any) bool {
+			return length(value) == n
+		},
+	)
+}
+
+// MinLen - checks if string has at least 'n' characters or slice has at least 'n' elements.
+//
+// Message arguments: '{len}'.
+func MinLen(n int) *Rule {
+	return newRule("min_len", "'{field}' should have length at least {len}", map[string]any{"len": n},
+		hasLength,
+		func(value any) bool {
+			return length(value) >= n
+		},
+	)
+}
+
+// MaxLen - checks if string has at most 'n' characters or slice has at most 'n' elements.
+//
+// Message arguments: '{len}'.
+func MaxLen(n int) *Rule {
+	return newRule("max_len", "'{field}' should have length at most {len}", map[string]any{"len": n},
+		hasLength,
+		func(value any) bool {
+			return length(value) <= n
+		},
+	)
+}
+
+// Match - checks if textual parameter matches regular expression 'pattern'.
+//
+// Panics if pattern can't be compiled. Message arguments: '{pattern}'.
+func Match(pattern string) *Rule {
+	var expression = regexp.MustCompile(pattern)
+
+	return newRule("match", "'{field}' should match '{pattern}'", map[string]any{"pattern": pattern},
+		isText,
+		func(value any) bool {
+			return expression.MatchString(text(value))
+		},
+	)
+}
+
+// Prefix - checks if textual parameter starts with 'prefix'.
+//
+// Message arguments: '{prefix}'.
+func Prefix(prefix string) *Rule {
+	return newRule("prefix", "'{field}' should start with '{prefix}'", map[string]any{"prefix": prefix},
+		isText,
+		func(value any) bool {
+			return strings.HasPrefix(text(value), prefix)
+		},
+	)
+}
+
+// Suffix - checks if textual parameter ends with 'suffix'.
+//
+// Message arguments: '{suffix}'.
+func Suffix(suffix string) *Rule {
+	return newRule("suffix", "'{field}' should end with '{suffix}'", map[string]any{"suffix": suffix},
+		isText,
+		func(value any) bool {
+			return strings.HasSuffix(text(value), suffix)
+		},
+	)
+}
+
+// Contains - checks if textual parameter contains 'substring'.
+//
+// Message arguments: '{substring}'.
+func Contains(substring string) *Rule {
+	return newRule("contains", "'{field}' should contain '{substring}'", map[string]any{"substring": substring},
+		isText,
+		func(value any) bool {
+			return strings.Contains(text(value), substring)
+		},
+	)
+}
+
+// OneOf - checks if parameter equals to one of 'values'.
+// Values are compared by their textual representation, so 'OneOf(1, 2)' works for integer parameters.
+//
+// Message arguments: '{values}'.
+func OneOf(values ...any) *Rule {
+	var set = textSet(values)
+
+	return newRule("one_of", "'{field}' should be one of: {values}", map[string]any{"values": join(values)},
+		anyType,
+		func(value any) bool {
+			return set[fmt.Sprint(value)]
+		},
+	)
+}
+
+// NotOneOf - checks if parameter doesn't equal to any of 'values'.
+// Values are compared by their textual representation.
+//
+// Message arguments: '{values}'.
+func NotOneOf(values ...any) *Rule {
+	var set = textSet(values)
+
+	return newRule("not_one_of", "'{field}' shouldn't be one of: {values}", map[string]any{"values": join(values)},
+		anyType,
+		func(value any) bool {
+			return !set[fmt.Sprint(value)]
+		},
+	)
+}
+
+// Before - checks if time parameter is before 't'.
+//
+// Message arguments: '{time}'.
+func Before(t time.Time) *Rule {
+	return newRule("before", "'{field}' should be before {time}", map[string]any{"time": t.Format(time.RFC3339)},
+		isTime,
+		func(value any) bool {
+			return value.(time.Time).Before(t)
+		},
+	)
+}
+
+// After - checks if time parameter is after 't'.
+//
+// Message arguments: '{time}'.
+func After(t time.Time) *Rule {
+	return newRule("after", "'{field}' should be after {time}", map[string]any{"time": t.Format(time.RFC3339)},
+		isTime,
+		func(value any) bool {
+			return value.(time.Time).After(t)
+		},
+	)
+}
+
+// Email - checks if textual parameter is bare email address like 'user@example.com'.
+var Email = newRule("email", "'{field}' should be valid email", nil,
+	isText,
+	func(value any) bool {
+		_, err := request.ParseEmail(text(value))
+
+		return err == nil
+	},
+)
+
+// URL - checks if textual parameter is absolute URL with scheme and host.
+var URL = newRule("url", "'{field}' should be valid URL", nil,
+	isText,
+	func(value any) bool {
+		_, err := request.ParseURL(text(value))
+
+		return err == nil
+	},
+)
+
+// UUID - checks if textual parameter is UUID in canonical form.
+var UUID = newRule("uuid", "'{field}' should be valid UUID", nil,
+	isText,
+	func(value any) bool {
+		_, err := request.ParseUUID(text(value))
+
+		return err == nil
+	},
+)
+
+func textSet(values []any) map[string]bool {
+	var set = make(map[string]bool, len(values))
+
+	for _, value := range values {
+		set[fmt.Sprint(value)] = true
+	}
+
+	return set
+}
+
+func join(values []any) string {
+	var result = make([]string, len(values))
+
+	for i, value := range values {
+		result[i] = fmt.Sprint(value)
+	}
+
+	return strings.Join(result, ", ")
+}
@@ -0,0 +1,458 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/types"
+)
+
+var ErrInvalidTag = errors.New("invalid 'validate' tag")
+
+// Struct - validates fields of structure using 'validate' tags, nested structures, slices and maps included:
+//
+//	type Order struct {
+//		Email string  `json:"email" validate:"required,email"`
+//		Items []Item  `json:"items" validate:"min=1,dive,required"`
+//		Note  *string `json:"note"  validate:"omitempty,max=100"`
+//	}
+//
+// Supported rules: 'required', 'omitempty', 'min', 'max', 'len', 'gt', 'lt', 'email', 'url', 'uuid',
+// 'oneof' (space separated values), 'prefix', 'suffix', 'contains' and 'dive' -
+// rules after it are applied to elements of slice or map.
+// 'min', 'max' and 'len' check length of strings, slices and maps and value of numbers.
+//
+// Structures implementing 'Validate() error' are checked by this method after their fields.
+// Failures are reported with paths of fields using JSON names (e.g. 'items[0].name').
+//
+// Used for every body requested by 'parameter.Body'.
+var Struct request.Option = structValidator{}
+
+// SelfValidator - structure checking itself after its fields were validated.
+type SelfValidator interface {
+	Validate() error
+}
+
+type structValidator struct{}
+
+func (structValidator) Validate(p *request.Parameter) error {
+	var failures types.ValidationErrors
+
+	walk(reflect.ValueOf(p.Parsed), "", &failures)
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return failures
+}
+
+// Check - checks that tags of structure (and nested ones) are valid.
+func (structValidator) Check(typ reflect.Type) error {
+	return checkType(typ, make(map[reflect.Type]bool))
+}
+
+// field - compiled rules of structure's field.
+type field struct {
+	index int
+	name  string
+	rules tagRules
+	dive  tagRules
+}
+
+type tagRules struct {
+	required  bool
+	omitempty bool
+	rules     []*Rule
+}
+
+// structs - cache of compiled structures: reflect.Type -> fieldsResult.
+var structs sync.Map
+
+type fieldsResult struct {
+	fields []field
+	err    error
+}
+
+func fieldsOf(typ reflect.Type) ([]field, error) {
+	if cached, ok := structs.Load(typ); ok {
+		var result = cached.(fieldsResult)
+
+		return result.fields, result.err
+	}
+
+	var result fieldsResult
+
+	result.fields, result.err = compile(typ)
+
+	structs.Store(typ, result)
+
+	return result.fields, result.err
+}
+
+func compile(typ reflect.Type) ([]field, error) {
+	var result []field
+
+	for i := 0; i < typ.NumField(); i++ {
+		var structField = typ.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name, skip := jsonName(structField)
+		if skip {
+			continue
+		}
+
+		var (
+			compiled     = field{index: i, name: name}
+			rules, dive  = types.ParseTag(structField.Tag.Get("validate"))
+			fieldType    = indirectType(structField.Type)
+			err          error
+			errWithField = func(err error) error {
+				return fmt.Errorf("%w: %s.%s: %w", ErrInvalidTag, typ, structField.Name, err)
+			}
+		)
+
+		if compiled.rules, err = compileRules(rules, fieldType); err != nil {
+			return nil, errWithField(err)
+		}
+
+		if len(dive) > 0 {
+			if kind := fieldType.Kind(); kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map {
+				return nil, errWithField(fmt.Errorf("'dive' can't be used for %s", fieldType))
+			}
+
+			if compiled.dive, err = compileRules(dive, indirectType(fieldType.Elem())); err != nil {
+				return nil, errWithField(err)
+			}
+		}
+
+		result = append(result, compiled)
+	}
+
+	return result, nil
+}
+
+func compileRules(rules []types.TagRule, typ reflect.Type) (tagRules, error) {
+	var result tagRules
+
+	for _, tag := range rules {
+		switch tag.Name {
+		case "required":
+			result.required = true
+
+			continue
+		case "omitempty":
+			result.omitempty = true
+
+			continue
+		}
+
+		rule, err := tagRule(tag, typ)
+		if err != nil {
+			return result, err
+		}
+
+		if err := rule.Check(typ); err != nil {
+			return result, err
+		}
+
+		result.rules = append(result.rules, rule)
+	}
+
+	return result, nil
+}
+
+// tagRule - returns rule described by tag, length rules are chosen for strings, slices and maps.
+func tagRule(tag types.TagRule, typ reflect.Type) (*Rule, error) {
+	var number = func() (float64, error) {
+		result, err := strconv.ParseFloat(tag.Param, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid parameter of '%s': '%s'", tag.Name, tag.Param)
+		}
+
+		return result, nil
+	}
+
+	switch tag.Name {
+	case "min", "max", "len":
+		n, err := number()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case tag.Name == "len":
+			return Len(int(n)), nil
+		case hasLength(typ) && typ != decimalType && tag.Name == "min":
+			return MinLen(int(n)), nil
+		case hasLength(typ) && typ != decimalType:
+			return MaxLen(int(n)), nil
+		case tag.Name == "min":
+			return Min(n), nil
+		default:
+			return Max(n), nil
+		}
+	case "gt", "lt":
+		n, err := number()
+		if err != nil {
+			return nil, err
+		}
+
+		if tag.Name == "gt" {
+			return Greater(n), nil
+		}
+
+		return Less(n), nil
+	case "email":
+		return Email, nil
+	case "url":
+		return URL, nil
+	case "uuid":
+		return UUID, nil
+	case "oneof":
+		var values []any
+
+		for _, value := range strings.Fields(tag.Param) {
+			values = append(values, value)
+		}
+
+		return OneOf(values...), nil
+	case "prefix":
+		return Prefix(tag.Param), nil
+	case "suffix":
+		return Suffix(tag.Param), nil
+	case "contains":
+		return Contains(tag.Param), nil
+	default:
+		return nil, fmt.Errorf("unknown rule '%s'", tag.Name)
+	}
+}
+
+// walk - validates value and all nested values, failures are reported with path of value.
+func walk(v reflect.Value, path string, failures *types.ValidationErrors) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields, err := fieldsOf(v.Type())
+		if err != nil {
+			*failures = append(*failures, types.ValidationError{Field: path, Rule: "struct", Message: err.Error()})
+
+			return
+		}
+
+		for _, field := range fields {
+			var (
+				value     = v.Field(field.index)
+				fieldPath = joinPath(path, field.name)
+			)
+
+			if !field.rules.apply(value, fieldPath, failures) {
+				continue
+			}
+
+			if len(field.dive.rules) > 0 || field.dive.required {
+				elements(value, fieldPath, func(element reflect.Value, path string) {
+					field.dive.apply(element, path, failures)
+				})
+			}
+
+			walk(value, fieldPath, failures)
+		}
+
+		selfValidate(v, path, failures)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if !isComposite(v.Type().Elem()) {
+			return
+		}
+
+		elements(v, path, func(element reflect.Value, path string) {
+			walk(element, path, failures)
+		})
+	}
+}
+
+// apply - checks value using rules, returns false if value is empty and shouldn't be checked further.
+func (rules tagRules) apply(v reflect.Value, path string, failures *types.ValidationErrors) bool {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			break
+		}
+
+		v = v.Elem()
+	}
+
+	if isEmpty(v) {
+		if rules.required {
+			*failures = append(*failures, types.ValidationError{
+				Field:   path,
+				Rule:    "required",
+				Message: fmt.Sprintf("'%s' is required", path),
+			})
+
+			return false
+		}
+
+		if rules.omitempty || !v.IsValid() || v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			return false
+		}
+	}
+
+	for _, rule := range rules.rules {
+		if err := rule.Validate(&request.Parameter{Name: path, Parsed: v.Interface()}); err != nil {
+			*failures = append(*failures, request.Failures(err, "", path, rule.name)...)
+		}
+	}
+
+	return true
+}
+
+// elements - calls 'f' for each element of slice, array or map (in order of keys).
+func elements(v reflect.Value, path string, f func(reflect.Value, string)) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			f(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		var keys = v.MapKeys()
+
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+
+		for _, key := range keys {
+			f(v.MapIndex(key), fmt.Sprintf("%s[%v]", path, key))
+		}
+	}
+}
+
+// selfValidate - calls 'Validate' method of structure if it has one.
+func selfValidate(v reflect.Value, path string, failures *types.ValidationErrors) {
+	var value = v.Interface()
+
+	if v.CanAddr() {
+		value = v.Addr().Interface()
+	}
+
+	validator, ok := value.(SelfValidator)
+	if !ok {
+		return
+	}
+
+	if err := validator.Validate(); err != nil {
+		*failures = append(*failures, request.Failures(err, "", path, "custom")...)
+	}
+}
+
+// checkType - checks tags of structure and all nested structures.
+func checkType(typ reflect.Type, visited map[reflect.Type]bool) error {
+	typ = indirectType(typ)
+
+	if visited[typ] {
+		return nil
+	}
+
+	visited[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		fields, err := fieldsOf(typ)
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			if err := checkType(typ.Field(field.index).Type, visited); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return checkType(typ.Elem(), visited)
+	}
+
+	return nil
+}
+
+func isEmpty(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+// isComposite - types which values can contain structures.
+func isComposite(typ reflect.Type) bool {
+	switch indirectType(typ).Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
+
+// jsonName - returns field name used by encoding/json, empty name for embedded structures.
+func jsonName(field reflect.StructField) (string, bool) {
+	var tag = field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+
+	switch {
+	case name != "":
+		return name, false
+	case field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct:
+		return "", false
+	default:
+		return field.Name, false
+	}
+}
+
+func joinPath(path, name string) string {
+	switch {
+	case path == "":
+		return name
+	case name == "":
+		return path
+	default:
+		return path + "." + name
+	}
+}
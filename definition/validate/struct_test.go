@@ -0,0 +1,194 @@
+package validate_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	Name  string `json:"name" validate:"required,max=5"`
+	Count int    `json:"count" validate:"gt=0"`
+}
+
+type order struct {
+	Email  string            `json:"email" validate:"required,email"`
+	Status string            `json:"status" validate:"oneof=new paid"`
+	Note   *string           `json:"note,omitempty" validate:"omitempty,min=3"`
+	Items  []item            `json:"items" validate:"min=1"`
+	Tags   []string          `json:"tags" validate:"dive,prefix=#"`
+	Labels map[string]string `json:"labels,omitempty" validate:"dive,required"`
+	Total  engi.Decimal      `json:"total,omitempty" validate:"omitempty,min=0.01,max=1000"`
+}
+
+func (o *order) Validate() error {
+	if o.Status == "paid" && len(o.Items) > 2 {
+		return errors.New("paid order can't have more than 2 items")
+	}
+
+	return nil
+}
+
+type orderService struct{}
+
+func (s *orderService) Prefix() string {
+	return "orders"
+}
+
+func (s *orderService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.PST(""): engi.Handle(
+			func(_ context.Context, _ engi.Request, resp engi.Response) error { return resp.NoContent() },
+			parameter.Body(new(order)),
+		),
+	}
+}
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "valid",
+			body:       `{"email":"user@example.com","status":"new","items":[{"name":"a","count":1}],"tags":["#a"]}`,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "fields",
+			body:       `{"status":"old","note":"ab","items":[],"total":"1000.5"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `[
+				{"location":"body","field":"email","rule":"required","message":"'email' is required"},
+				{"location":"body","field":"status","rule":"one_of","message":"'status' should be one of: new, paid"},
+				{"location":"body","field":"note","rule":"min_len","message":"'note' should have length at least 3"},
+				{"location":"body","field":"items","rule":"min_len","message":"'items' should have length at least 1"},
+				{"location":"body","field":"total","rule":"max","message":"'total' should be at most 1000"}
+			]`,
+		},
+		{
+			name:       "nested",
+			body:       `{"email":"user.example.com","status":"new","items":[{"name":"a","count":1},{"name":"abcdef","count":0}],"tags":["#a","b"],"labels":{"x":""}}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody: `[
+				{"location":"body","field":"email","rule":"email","message":"'email' should be valid email"},
+				{"location":"body","field":"items[1].name","rule":"max_len","message":"'items[1].name' should have length at most 5"},
+				{"location":"body","field":"items[1].count","rule":"gt","message":"'items[1].count' should be greater than 0"},
+				{"location":"body","field":"tags[1]","rule":"prefix","message":"'tags[1]' should start with '#'"},
+				{"location":"body","field":"labels[x]","rule":"required","message":"'labels[x]' is required"}
+			]`,
+		},
+		{
+			name:       "self validation",
+			body:       `{"email":"user@example.com","status":"paid","items":[{"name":"a","count":1},{"name":"b","count":1},{"name":"c","count":1}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `[{"location":"body","rule":"custom","message":"paid order can't have more than 2 items"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		// Body is decoded into the same pointer, so every case gets its own engine.
+		var engine = engi.New("")
+
+		assert.NoError(t, engine.RegisterServices(&orderService{}))
+
+		var server = httptest.NewServer(engine.Handler())
+
+		resp, err := http.Post(server.URL+"/orders/", "application/json", strings.NewReader(tt.body))
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		server.Close()
+
+		assert.Equal(t, tt.wantStatus, resp.StatusCode, tt.name)
+
+		if tt.wantBody != "" {
+			assert.JSONEq(t, tt.wantBody, string(body), tt.name)
+		}
+	}
+}
+
+func TestStructDocs(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&orderService{}))
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]struct {
+					Schema struct {
+						Required   []string                  `json:"required"`
+						Properties map[string]map[string]any `json:"properties"`
+					} `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+
+	var schema = document.Paths["/orders/"]["post"].RequestBody.Content["application/json"].Schema
+
+	assert.Equal(t, []string{"email", "status", "items", "tags"}, schema.Required)
+	assert.Equal(t, "email", schema.Properties["email"]["format"])
+	assert.Equal(t, []any{"new", "paid"}, schema.Properties["status"]["enum"])
+	assert.Equal(t, float64(3), schema.Properties["note"]["minLength"])
+	assert.Equal(t, float64(1), schema.Properties["items"]["minItems"])
+	assert.Equal(t, map[string]any{"type": "string", "pattern": "^#"}, schema.Properties["tags"]["items"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "decimal", "minimum": 0.01, "maximum": float64(1000)},
+		schema.Properties["total"])
+
+	var count = schema.Properties["items"]["items"].(map[string]any)["properties"].(map[string]any)["count"]
+	assert.Equal(t, map[string]any{"type": "integer", "format": "int32", "minimum": float64(0), "exclusiveMinimum": true}, count)
+}
+
+type badTag struct {
+	Name string `json:"name" validate:"required,unknown"`
+}
+
+type badType struct {
+	Age int `json:"age" validate:"email"`
+}
+
+type badTagService struct {
+	pointer any
+}
+
+func (s *badTagService) Prefix() string {
+	return "bad"
+}
+
+func (s *badTagService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.PST(""): engi.Handle(
+			func(context.Context, engi.Request, engi.Response) error { return nil },
+			parameter.Body(s.pointer),
+		),
+	}
+}
+
+func TestStructInvalidTags(t *testing.T) {
+	var err = engi.New("").RegisterServices(&badTagService{pointer: new(badTag)})
+	assert.ErrorIs(t, err, validate.ErrInvalidTag)
+
+	err = engi.New("").RegisterServices(&badTagService{pointer: new([]badType)})
+	assert.ErrorIs(t, err, validate.ErrUnsupportedType)
+}
@@ -0,0 +1,118 @@
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/parameter/query"
+	"github.com/kliuchnikovv/engi/definition/validate"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(t *testing.T) {
+	var (
+		now      = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		link, _  = url.Parse("https://example.com/path")
+		positive = validate.Greater(0)
+	)
+
+	tests := []struct {
+		name    string
+		option  request.Option
+		value   any
+		wantErr string
+	}{
+		{"min passed", validate.Min(1), int64(1), ""},
+		{"min failed", validate.Min(1), int64(0), "'p' should be at least 1"},
+		{"max uint", validate.Max(10), uint64(11), "'p' should be at most 10"},
+		{"between float", validate.Between(0.5, 1.5), 1.5, ""},
+		{"between decimal", validate.Between(0, 1), request.Decimal("1.000000000000000001"), "'p' should be between 0 and 1"},
+		{"multiple of", validate.MultipleOf(0.25), 1.75, ""},
+		{"not multiple of", validate.MultipleOf(3), int64(10), "'p' should be multiple of 3"},
+		{"len runes", validate.Len(3), "añb", ""},
+		{"min len slice", validate.MinLen(2), []int64{1}, "'p' should have length at least 2"},
+		{"max len", validate.MaxLen(2), "abc", "'p' should have length at most 2"},
+		{"match", validate.Match(`^[a-z]+$`), "abc", ""},
+		{"match stringer", validate.Match(`^https://`), link, ""},
+		{"match failed", validate.Match(`^\d+$`), "abc", `'p' should match '^\d+$'`},
+		{"prefix", validate.Prefix("ab"), "abc", ""},
+		{"suffix", validate.Suffix("x"), "abc", "'p' should end with 'x'"},
+		{"contains", validate.Contains("example"), link, ""},
+		{"one of", validate.OneOf(1, 2), int64(2), ""},
+		{"one of failed", validate.OneOf("asc", "desc"), "up", "'p' should be one of: asc, desc"},
+		{"not one of", validate.NotOneOf("admin"), "admin", "'p' shouldn't be one of: admin"},
+		{"before", validate.Before(now), now.Add(-time.Second), ""},
+		{"after", validate.After(now), now, "'p' should be after 2024-01-02T03:04:05Z"},
+		{"greater time", validate.Greater(float64(now.Unix())), now, "'p' should be greater than 1704164645"},
+		{"less time", validate.Less(float64(now.Unix() + 1)), now, ""},
+		{"less string length", validate.Less(3), "ab", ""},
+		{"not", validate.NOT(positive), int64(-1), ""},
+		{"not failed", validate.NOT(positive), int64(1), "'p' shouldn't pass 'gt' check"},
+		{"not empty", validate.NotEmpty, "", "'p' shouldn't be empty"},
+		{"not empty bool", validate.NotEmpty, false, ""},
+		{"message", validate.Min(18).Message("{field} must be {min}+, got {value}"), int64(5), "p must be 18+, got 5"},
+		{"or", validate.OR(validate.Less(0), validate.Greater(10)), int64(5),
+			"'p' failed check: 'p' should be less than 0 and 'p' should be greater than 10"},
+		{"unsupported at runtime", validate.Min(1), "abc", "'p': unsupported type: rule 'min' can't validate string"},
+	}
+
+	for _, tt := range tests {
+		var err = tt.option.Validate(&request.Parameter{Name: "p", Parsed: tt.value})
+
+		if tt.wantErr == "" {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.EqualError(t, err, tt.wantErr, tt.name)
+		}
+	}
+}
+
+func TestAND(t *testing.T) {
+	var err = validate.AND(validate.Min(10), validate.MultipleOf(2)).
+		Validate(&request.Parameter{Name: "p", Parsed: int64(5)})
+
+	var failures types.ValidationErrors
+	assert.True(t, errors.As(err, &failures))
+	assert.Equal(t, types.ValidationErrors{
+		{Rule: "min", Message: "'p' should be at least 10"},
+		{Rule: "multiple_of", Message: "'p' should be multiple of 2"},
+	}, failures)
+}
+
+type invalidService struct {
+	option request.Option
+}
+
+func (s *invalidService) Prefix() string {
+	return "invalid"
+}
+
+func (s *invalidService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET(""): engi.Handle(
+			func(context.Context, engi.Request, engi.Response) error { return nil },
+			query.Bool("flag", s.option),
+		),
+	}
+}
+
+func TestUnsupportedTypeOnRegistration(t *testing.T) {
+	for _, option := range []request.Option{
+		validate.Min(1),
+		validate.MaxLen(1),
+		validate.AND(validate.NotEmpty, validate.Before(time.Now())),
+		validate.NOT(validate.Match("x")),
+	} {
+		var err = engi.New("").RegisterServices(&invalidService{option: option})
+
+		assert.ErrorIs(t, err, validate.ErrUnsupportedType)
+	}
+
+	assert.NoError(t, engi.New("").RegisterServices(&invalidService{option: validate.OneOf(true)}))
+}
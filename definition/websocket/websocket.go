@@ -0,0 +1,122 @@
+// Package websocket - settings of 'engi.HandleWebSocket' routes and client for connecting to them.
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+	"github.com/kliuchnikovv/engi/internal/websocket"
+)
+
+type (
+	// MessageType - type of data message.
+	MessageType = websocket.MessageType
+	// StatusCode - code of close frame.
+	StatusCode = websocket.StatusCode
+	// CloseError - close frame got from peer, returned by reading after connection was closed.
+	CloseError = websocket.CloseError
+)
+
+const (
+	TextMessage   = websocket.TextMessage
+	BinaryMessage = websocket.BinaryMessage
+
+	StatusNormalClosure   = websocket.StatusNormalClosure
+	StatusGoingAway       = websocket.StatusGoingAway
+	StatusProtocolError   = websocket.StatusProtocolError
+	StatusUnsupportedData = websocket.StatusUnsupportedData
+	StatusNoStatus        = websocket.StatusNoStatus
+	StatusInvalidPayload  = websocket.StatusInvalidPayload
+	StatusPolicyViolation = websocket.StatusPolicyViolation
+	StatusMessageTooBig   = websocket.StatusMessageTooBig
+	StatusInternalError   = websocket.StatusInternalError
+
+	// DefaultReadLimit - limit of incoming message size used if 'ReadLimit' isn't set.
+	DefaultReadLimit = websocket.DefaultReadLimit
+	// DefaultPingInterval - interval of pings used if 'PingInterval' isn't set.
+	DefaultPingInterval = websocket.DefaultPingInterval
+)
+
+var (
+	// ErrClosed - connection is closed, nothing can be written to it.
+	ErrClosed = websocket.ErrClosed
+	// ErrBadHandshake - server refused to upgrade connection, see response returned by 'Dial'.
+	ErrBadHandshake = websocket.ErrBadHandshake
+)
+
+// ReadLimit - sets limit of incoming message size in bytes,
+// connection is closed with 'StatusMessageTooBig' if peer sends bigger message.
+//
+// Panics if limit isn't positive.
+func ReadLimit(bytes int64) routes.Middleware {
+	if bytes <= 0 {
+		panic(fmt.Sprintf("invalid read limit: %d", bytes))
+	}
+
+	return setting(func(options *websocket.Options) {
+		options.ReadLimit = bytes
+	})
+}
+
+// PingInterval - sets interval of keepalive pings, connection is closed if peer doesn't respond for two intervals.
+// Zero disables pings.
+//
+// Panics if interval is negative.
+func PingInterval(interval time.Duration) routes.Middleware {
+	if interval < 0 {
+		panic(fmt.Sprintf("invalid ping interval: %s", interval))
+	}
+
+	return setting(func(options *websocket.Options) {
+		options.PingInterval = interval
+	})
+}
+
+// Compression - enables permessage-deflate compression of messages if peer supports it.
+func Compression() routes.Middleware {
+	return setting(func(options *websocket.Options) {
+		options.Compression = true
+	})
+}
+
+// Dial - connects to WebSocket route at 'ws://' or 'wss://' URL, e.g. in tests.
+// Settings are applied to client's side of connection.
+// Response of failed handshake is returned along with 'ErrBadHandshake'.
+func Dial(
+	ctx context.Context,
+	url string,
+	header http.Header,
+	settings ...routes.Middleware,
+) (*websocket.Conn, *http.Response, error) {
+	var options = websocket.DefaultOptions()
+
+	for _, middleware := range settings {
+		if setting, ok := middleware.(websocket.Setting); ok {
+			setting.Configure(&options)
+		}
+	}
+
+	return websocket.Dial(ctx, url, header, options)
+}
+
+// setting - changes options of route's connections, applied on registration by 'engi.HandleWebSocket'.
+type setting func(*websocket.Options)
+
+func (configure setting) Configure(options *websocket.Options) {
+	configure(options)
+}
+
+func (setting) Handle(context.Context, *request.Request, *response.Response) error {
+	return nil
+}
+
+func (setting) Docs(*routes.Route) {}
+
+func (setting) Priority() int {
+	return 0
+}
@@ -0,0 +1,229 @@
+package websocket_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kliuchnikovv/engi"
+	"github.com/kliuchnikovv/engi/definition/middlewares/auth"
+	"github.com/kliuchnikovv/engi/definition/websocket"
+	"github.com/kliuchnikovv/engi/internal/routes"
+	"github.com/stretchr/testify/assert"
+)
+
+type chatMessage struct {
+	Text string `json:"text"`
+}
+
+type chatService struct {
+	// closed - errors handlers got after connection was closed.
+	closed chan error
+}
+
+func (s *chatService) Prefix() string {
+	return "chat"
+}
+
+func (s *chatService) Routers() engi.Routes {
+	return engi.Routes{
+		engi.GET("echo"): engi.HandleWebSocket(s.echo,
+			auth.Basic("user", "secret"),
+			websocket.ReadLimit(64<<10),
+			websocket.PingInterval(50*time.Millisecond),
+			websocket.Compression(),
+		),
+		engi.GET("wait"): engi.HandleWebSocket(s.wait),
+	}
+}
+
+func (s *chatService) echo(_ context.Context, _ engi.Request, conn *engi.Conn) error {
+	for {
+		var message chatMessage
+
+		if err := conn.ReadJSON(&message); err != nil {
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) {
+				return nil
+			}
+
+			return err
+		}
+
+		if err := conn.WriteJSON(chatMessage{Text: "echo: " + message.Text}); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *chatService) wait(ctx context.Context, _ engi.Request, _ *engi.Conn) error {
+	<-ctx.Done()
+
+	s.closed <- ctx.Err()
+
+	return nil
+}
+
+func dial(t *testing.T, url string, settings ...routes.Middleware) *engi.Conn {
+	var header = make(http.Header)
+	header.Set("Authorization", "Basic dXNlcjpzZWNyZXQ=")
+
+	conn, _, err := websocket.Dial(context.Background(), url, header, settings...)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	return conn
+}
+
+func TestWebSocket(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&chatService{}))
+
+	var server = httptest.NewServer(engine.Handler())
+	defer server.Close()
+
+	var url = "ws" + strings.TrimPrefix(server.URL, "http") + "/chat/echo"
+
+	// Middlewares reject request before connection is upgraded.
+	_, resp, err := websocket.Dial(context.Background(), url, nil)
+	assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Plain requests aren't upgraded.
+	resp, err = http.Get(server.URL + "/chat/echo")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	tests := []struct {
+		name       string
+		settings   []routes.Middleware
+		compressed bool
+	}{
+		{"plain", nil, false},
+		{"compressed", []routes.Middleware{websocket.Compression()}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conn = dial(t, url, append(tt.settings, websocket.PingInterval(0))...)
+			defer conn.Close(websocket.StatusNormalClosure, "")
+
+			assert.Equal(t, tt.compressed, conn.Compressed())
+
+			for _, text := range []string{"hello", strings.Repeat("long ", 10000)} {
+				var got chatMessage
+
+				assert.NoError(t, conn.WriteJSON(chatMessage{Text: text}))
+				assert.NoError(t, conn.ReadJSON(&got))
+				assert.Equal(t, "echo: "+text, got.Text)
+			}
+
+			// Connection stays alive while pings are answered.
+			time.Sleep(200 * time.Millisecond)
+
+			assert.NoError(t, conn.WriteJSON(chatMessage{Text: "still here"}))
+			assert.NoError(t, conn.ReadJSON(new(chatMessage)))
+		})
+	}
+
+	t.Run("too big", func(t *testing.T) {
+		var conn = dial(t, url)
+		defer conn.Close(websocket.StatusNormalClosure, "")
+
+		assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(strings.Repeat("a", 65<<10))))
+
+		var closeErr *websocket.CloseError
+
+		_, _, err := conn.ReadMessage()
+		if assert.ErrorAs(t, err, &closeErr) {
+			assert.Equal(t, websocket.StatusMessageTooBig, closeErr.Code)
+		}
+
+		assert.ErrorIs(t, conn.WriteMessage(websocket.TextMessage, []byte("a")), websocket.ErrClosed)
+	})
+
+	t.Run("invalid text", func(t *testing.T) {
+		var conn = dial(t, url)
+		defer conn.Close(websocket.StatusNormalClosure, "")
+
+		assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte{0xff, 0xfe}))
+
+		var closeErr *websocket.CloseError
+
+		_, _, err := conn.ReadMessage()
+		if assert.ErrorAs(t, err, &closeErr) {
+			assert.Equal(t, websocket.StatusInvalidPayload, closeErr.Code)
+		}
+	})
+}
+
+func TestWebSocketShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	var address = listener.Addr().String()
+	listener.Close()
+
+	var (
+		service = &chatService{closed: make(chan error, 1)}
+		engine  = engi.New(address)
+	)
+
+	assert.NoError(t, engine.RegisterServices(service))
+
+	go engine.Start()
+
+	var conn *engi.Conn
+
+	assert.Eventually(t, func() bool {
+		conn, _, err = websocket.Dial(context.Background(), "ws://"+address+"/chat/wait", nil)
+
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	engine.Shutdown(context.Background())
+
+	var closeErr *websocket.CloseError
+
+	_, _, err = conn.ReadMessage()
+	if assert.ErrorAs(t, err, &closeErr) {
+		assert.Equal(t, websocket.StatusGoingAway, closeErr.Code)
+	}
+
+	select {
+	case err := <-service.closed:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("handler wasn't stopped")
+	}
+}
+
+func TestWebSocketDocs(t *testing.T) {
+	var engine = engi.New("")
+
+	assert.NoError(t, engine.RegisterServices(&chatService{}))
+
+	spec, err := engine.OpenAPI()
+	assert.NoError(t, err)
+
+	var document struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+	}
+
+	assert.NoError(t, json.Unmarshal(spec, &document))
+	assert.Contains(t, document.Paths["/chat/echo"]["get"].Responses, "101")
+
+	assert.Panics(t, func() { websocket.ReadLimit(0) })
+	assert.Panics(t, func() { websocket.PingInterval(-time.Second) })
+}
@@ -1,17 +1,21 @@
 package engi
 
 import (
+	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"os"
 	"time"
 
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"github.com/kliuchnikovv/engi/internal/types"
+	"github.com/kliuchnikovv/engi/internal/websocket"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 )
 
-// TODO: add checking length of request from comments about field length
 // TODO: benchmarks
 // TODO: tests
 // TODO: logging (log url usages)
@@ -20,20 +24,28 @@ import (
 const (
 	defaultAddress = ":8080"
 	defaultTimeout = 5 * time.Second
+	defaultTitle   = "engi"
+	defaultVersion = "1.0.0"
 )
 
 // Engine - server provider.
 type Engine struct {
-	apiPrefix string
-	services  []*Service
+	apiPrefix   string
+	services    []*Service
+	middlewares []Middleware
 
 	responseMarshaler types.Marshaler
 	responseObject    types.Responser
+	codecs            *codec.Registry
 
 	server *http.Server
 	logger *slog.Logger
+	docs   *docs.Document
 
 	tracerProvider trace.TracerProvider
+	trustedProxies []netip.Prefix
+	maxBodySize    int64
+	websockets     *websocket.Hub
 
 	signalChan chan os.Signal
 }
@@ -47,6 +59,7 @@ func New(address string, configs ...Option) *Engine {
 	var engine = &Engine{
 		responseObject:    new(types.ResponseAsIs),
 		responseMarshaler: types.NewJSONMarshaler(),
+		codecs:            codec.Default(),
 		server: &http.Server{
 			Addr:              address,
 			ReadTimeout:       defaultTimeout,
@@ -55,7 +68,9 @@ func New(address string, configs ...Option) *Engine {
 			ReadHeaderTimeout: defaultTimeout,
 		},
 		logger:         slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		docs:           docs.New(defaultTitle, defaultVersion),
 		tracerProvider: otel.GetTracerProvider(),
+		websockets:     websocket.NewHub(),
 		signalChan:     make(chan os.Signal, 1),
 	}
 
@@ -63,5 +78,14 @@ func New(address string, configs ...Option) *Engine {
 		config(engine)
 	}
 
+	// Hijacked connections aren't tracked by server, so they're closed on shutdown separately.
+	engine.server.RegisterOnShutdown(engine.websockets.Shutdown)
+
 	return engine
 }
+
+// OpenAPI - returns OpenAPI specification of registered services in JSON.
+// Should be called after 'RegisterServices'.
+func (e *Engine) OpenAPI() ([]byte, error) {
+	return json.Marshal(e.docs)
+}
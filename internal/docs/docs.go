@@ -1 +1,340 @@
 package docs
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/types"
+)
+
+const openAPIVersion = "3.0.3"
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// New - creates empty document.
+func New(title, version string) *Document {
+	return &Document{
+		OpenAPI: openAPIVersion,
+		Info: Info{
+			Title:   title,
+			Version: version,
+		},
+		Paths: make(map[string]map[string]*Operation),
+	}
+}
+
+// NewOperation - creates operation with default response.
+func NewOperation() *Operation {
+	return &Operation{
+		Responses: map[string]*Response{
+			"default": {Description: http.StatusText(http.StatusOK)},
+		},
+	}
+}
+
+// Add - adds operation to document by method and path.
+// Path parameters in engi format (':id', '*path') converted to OpenAPI format ('{id}', '{path}').
+func (doc *Document) Add(method, path string, operation *Operation) {
+	var segments = strings.Split(path, "/")
+
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	path = strings.Join(segments, "/")
+
+	if doc.Paths[path] == nil {
+		doc.Paths[path] = make(map[string]*Operation)
+	}
+
+	doc.Paths[path][strings.ToLower(method)] = operation
+
+	for name, scheme := range operation.SecuritySchemes {
+		if doc.Components == nil {
+			doc.Components = &Components{
+				SecuritySchemes: make(map[string]*SecurityScheme),
+			}
+		}
+
+		doc.Components.SecuritySchemes[name] = scheme
+	}
+}
+
+// AddParameter - adds parameter to operation replacing parameter with the same name and placing.
+func (operation *Operation) AddParameter(parameter *Parameter) {
+	for i, p := range operation.Parameters {
+		if p.Name == parameter.Name && p.In == parameter.In {
+			operation.Parameters[i] = parameter
+
+			return
+		}
+	}
+
+	operation.Parameters = append(operation.Parameters, parameter)
+}
+
+// AddFormField - adds field to schema of form body replacing field with the same name.
+// Files can be sent only in 'multipart/form-data' body, so other form's media types are removed.
+func (operation *Operation) AddFormField(name string, schema *Schema, required bool, contentTypes ...string) {
+	const (
+		urlencoded = "application/x-www-form-urlencoded"
+		multipart  = "multipart/form-data"
+	)
+
+	var body = operation.RequestBody
+	if body == nil || body.Content[multipart] == nil {
+		var object = &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+		body = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				urlencoded: {Schema: object},
+				multipart:  {Schema: object},
+			},
+		}
+		operation.RequestBody = body
+	}
+
+	var media = body.Content[multipart]
+
+	media.Schema.Properties[name] = schema
+
+	if required && !slices.Contains(media.Schema.Required, name) {
+		media.Schema.Required = append(media.Schema.Required, name)
+	}
+
+	if schema.Format == "binary" {
+		delete(body.Content, urlencoded)
+	}
+
+	if len(contentTypes) > 0 {
+		if media.Encoding == nil {
+			media.Encoding = make(map[string]*Encoding)
+		}
+
+		media.Encoding[name] = &Encoding{ContentType: strings.Join(contentTypes, ", ")}
+	}
+}
+
+// LimitBody - describes limit of request body and response for bodies exceeding it.
+func (operation *Operation) LimitBody(size int64) {
+	operation.MaxBodySize = size
+	operation.Responses[strconv.Itoa(http.StatusRequestEntityTooLarge)] = &Response{
+		Description: fmt.Sprintf("%s: body exceeds %d bytes", http.StatusText(http.StatusRequestEntityTooLarge), size),
+	}
+}
+
+// SchemaOf - creates schema describing type.
+func SchemaOf(typ reflect.Type) *Schema {
+	return schemaOf(typ, make(map[reflect.Type]bool))
+}
+
+func schemaOf(typ reflect.Type, visited map[reflect.Type]bool) *Schema {
+	if typ == nil {
+		return &Schema{}
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == reflect.TypeOf(time.Time{}) {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	// Decimals are kept as strings, so no precision is lost.
+	if typ == reflect.TypeOf(request.Decimal("")) {
+		return &Schema{Type: "string", Format: "decimal"}
+	}
+
+	// Such types are encoded as strings.
+	if typ.Implements(textMarshalerType) || reflect.PointerTo(typ).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: schemaOf(typ.Elem(), visited)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOf(typ.Elem(), visited)}
+	case reflect.Struct:
+		return structSchema(typ, visited)
+	default:
+		return &Schema{}
+	}
+}
+
+func structSchema(typ reflect.Type, visited map[reflect.Type]bool) *Schema {
+	var schema = Schema{Type: "object"}
+
+	// Recursive type - properties already described above.
+	if visited[typ] {
+		return &schema
+	}
+
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	schema.Properties = make(map[string]*Schema)
+
+	for i := 0; i < typ.NumField(); i++ {
+		var field = typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitempty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		var (
+			property    = schemaOf(field.Type, visited)
+			rules, dive = types.ParseTag(field.Tag.Get("validate"))
+		)
+
+		schema.Properties[name] = property
+
+		constrain(property, rules)
+
+		if len(dive) > 0 {
+			switch {
+			case property.Items != nil:
+				constrain(property.Items, dive)
+			case property.AdditionalProperties != nil:
+				constrain(property.AdditionalProperties, dive)
+			}
+		}
+
+		if (!omitempty && field.Type.Kind() != reflect.Pointer) || hasRule(rules, "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return &schema
+}
+
+// jsonName - returns field name used by encoding/json.
+func jsonName(field reflect.StructField) (string, bool, bool) {
+	var tag = field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, strings.Contains(options, "omitempty"), false
+}
+
+// constrain - describes rules of 'validate' tag as schema constraints, unknown rules are skipped.
+func constrain(schema *Schema, rules []types.TagRule) {
+	for _, rule := range rules {
+		var (
+			number, err = strconv.ParseFloat(rule.Param, 64)
+			length      = int(number)
+			numeric     = schema.Type == "integer" || schema.Type == "number" || schema.Format == "decimal"
+		)
+
+		switch rule.Name {
+		case "min", "max", "len":
+			if err != nil {
+				continue
+			}
+
+			switch {
+			case numeric && rule.Name != "len":
+				if rule.Name == "min" {
+					schema.Minimum = &number
+				} else {
+					schema.Maximum = &number
+				}
+			case schema.Type == "string":
+				if rule.Name != "max" {
+					schema.MinLength = &length
+				}
+
+				if rule.Name != "min" {
+					schema.MaxLength = &length
+				}
+			case schema.Type == "array":
+				if rule.Name != "max" {
+					schema.MinItems = &length
+				}
+
+				if rule.Name != "min" {
+					schema.MaxItems = &length
+				}
+			}
+		case "gt", "lt":
+			if err != nil || !numeric {
+				continue
+			}
+
+			if rule.Name == "gt" {
+				schema.Minimum, schema.ExclusiveMinimum = &number, true
+			} else {
+				schema.Maximum, schema.ExclusiveMaximum = &number, true
+			}
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "uuid":
+			schema.Format = "uuid"
+		case "oneof":
+			for _, value := range strings.Fields(rule.Param) {
+				if n, err := strconv.ParseFloat(value, 64); err == nil && numeric {
+					schema.Enum = append(schema.Enum, n)
+				} else {
+					schema.Enum = append(schema.Enum, value)
+				}
+			}
+		case "prefix":
+			schema.Pattern = "^" + regexp.QuoteMeta(rule.Param)
+		case "suffix":
+			schema.Pattern = regexp.QuoteMeta(rule.Param) + "$"
+		case "contains":
+			schema.Pattern = regexp.QuoteMeta(rule.Param)
+		}
+	}
+}
+
+func hasRule(rules []types.TagRule, name string) bool {
+	for _, rule := range rules {
+		if rule.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,2 +1,101 @@
 package docs
 
+// Subset of OpenAPI 3.0 specification used to describe routes.
+
+type (
+	Document struct {
+		OpenAPI    string                           `json:"openapi"`
+		Info       Info                             `json:"info"`
+		Paths      map[string]map[string]*Operation `json:"paths"`
+		Components *Components                      `json:"components,omitempty"`
+	}
+
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	}
+
+	Components struct {
+		SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+	}
+
+	Operation struct {
+		Tags        []string              `json:"tags,omitempty"`
+		Description string                `json:"description,omitempty"`
+		Parameters  []*Parameter          `json:"parameters,omitempty"`
+		RequestBody *RequestBody          `json:"requestBody,omitempty"`
+		Responses   map[string]*Response  `json:"responses"`
+		Security    []map[string][]string `json:"security,omitempty"`
+
+		// MaxBodySize - limit of request body in bytes (OpenAPI extension).
+		MaxBodySize int64 `json:"x-max-body-size,omitempty"`
+
+		// SecuritySchemes - schemes used by operation, moved to document's components.
+		SecuritySchemes map[string]*SecurityScheme `json:"-"`
+	}
+
+	Parameter struct {
+		Name        string  `json:"name"`
+		In          string  `json:"in"`
+		Description string  `json:"description,omitempty"`
+		Required    bool    `json:"required"`
+		Style       string  `json:"style,omitempty"`
+		Explode     *bool   `json:"explode,omitempty"`
+		Schema      *Schema `json:"schema"`
+	}
+
+	RequestBody struct {
+		Description string                `json:"description,omitempty"`
+		Required    bool                  `json:"required"`
+		Content     map[string]*MediaType `json:"content"`
+	}
+
+	Response struct {
+		Description string                `json:"description"`
+		Headers     map[string]*Header    `json:"headers,omitempty"`
+		Content     map[string]*MediaType `json:"content,omitempty"`
+	}
+
+	Header struct {
+		Description string  `json:"description,omitempty"`
+		Schema      *Schema `json:"schema"`
+	}
+
+	MediaType struct {
+		Schema   *Schema              `json:"schema,omitempty"`
+		Encoding map[string]*Encoding `json:"encoding,omitempty"`
+	}
+
+	// Encoding - encoding of form's field, e.g. content types of uploaded file.
+	Encoding struct {
+		ContentType string `json:"contentType,omitempty"`
+	}
+
+	Schema struct {
+		Type                 string             `json:"type,omitempty"`
+		Format               string             `json:"format,omitempty"`
+		Pattern              string             `json:"pattern,omitempty"`
+		Default              any                `json:"default,omitempty"`
+		Enum                 []any              `json:"enum,omitempty"`
+		Minimum              *float64           `json:"minimum,omitempty"`
+		Maximum              *float64           `json:"maximum,omitempty"`
+		ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+		MinLength            *int               `json:"minLength,omitempty"`
+		MaxLength            *int               `json:"maxLength,omitempty"`
+		Items                *Schema            `json:"items,omitempty"`
+		MinItems             *int               `json:"minItems,omitempty"`
+		MaxItems             *int               `json:"maxItems,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+		Required             []string           `json:"required,omitempty"`
+		Nullable             bool               `json:"nullable,omitempty"`
+	}
+
+	SecurityScheme struct {
+		Type   string `json:"type"`
+		Scheme string `json:"scheme,omitempty"`
+		Name   string `json:"name,omitempty"`
+		In     string `json:"in,omitempty"`
+	}
+)
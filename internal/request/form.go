@@ -0,0 +1,408 @@
+package request
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/kliuchnikovv/engi/definition/parameter/placing"
+	"github.com/kliuchnikovv/engi/internal/types"
+)
+
+const (
+	// LocationForm - location of validation failures of form fields and files.
+	LocationForm = "form"
+
+	// maxFormMemory - limit of all non-file fields of multipart form kept in memory.
+	maxFormMemory = 10 << 20
+	// maxFormFiles - limit of files in multipart form, every file is limited by its upload's size,
+	// so files can't fill disk even without limit of body.
+	maxFormFiles = 32
+	// sniffLength - number of bytes used to detect content type of file.
+	sniffLength = 512
+)
+
+var (
+	// ErrNotForm - request's content type isn't 'application/x-www-form-urlencoded' or 'multipart/form-data'.
+	ErrNotForm = errors.New("request body is not a form")
+	// ErrFileTooLarge - uploaded file exceeds its limit.
+	ErrFileTooLarge = errors.New("file too large")
+)
+
+// Upload - settings of file field of multipart form.
+type Upload struct {
+	// MaxSize - limit of file in bytes.
+	MaxSize int64
+	// AllowedTypes - allowed content types detected by file's content (e.g. 'image/png' or 'image/*'),
+	// any type allowed if empty.
+	AllowedTypes []string
+	// Open - returns writer file is streamed to, file is saved to temporary file if nil.
+	// Writer is closed after file is written if it implements 'io.Closer'.
+	Open func(*File) (io.Writer, error)
+}
+
+// File - file uploaded with multipart form.
+type File struct {
+	// Field - name of form's field.
+	Field string
+	// Filename - name of file sent by client, directories are stripped.
+	Filename string
+	// Size - size of file in bytes.
+	Size int64
+	// ContentType - type detected by file's content.
+	ContentType string
+	// Header - headers of form's part.
+	Header textproto.MIMEHeader
+
+	path string
+}
+
+// Open - opens temporary file content was saved to.
+// Returns error if file was streamed to writer set by 'form.FileTo'.
+func (file *File) Open() (io.ReadCloser, error) {
+	if file.path == "" {
+		return nil, fmt.Errorf("file '%s' was not saved to temporary file", file.Filename)
+	}
+
+	return os.Open(file.path)
+}
+
+// Path - returns path of temporary file content was saved to, empty if file was streamed to writer.
+// Temporary file is removed after request is served.
+func (file *File) Path() string {
+	return file.path
+}
+
+// form - state of parsed form body.
+type form struct {
+	parsed   bool
+	err      error
+	uploads  map[string]Upload
+	fields   map[string]struct{}
+	memory   int64
+	uploaded int
+	files    map[string][]*File
+	failures map[string]types.ValidationErrors
+}
+
+// SetUploads - sets settings of files expected in multipart form.
+func SetUploads(r *Request, uploads map[string]Upload) {
+	r.form.uploads = uploads
+}
+
+// SetFields - sets names of non-file fields read from form, other fields are skipped.
+func SetFields(r *Request, fields map[string]struct{}) {
+	r.form.fields = fields
+}
+
+// ParseForm - reads 'application/x-www-form-urlencoded' or 'multipart/form-data' body once,
+// fields set by 'SetFields' are saved as 'placing.InForm' parameters, expected files are streamed to their destination.
+// Returned error wraps 'ErrBodyTooLarge' if body exceeds limit set by 'SetMaxBodySize' or has more than 32 files.
+func ParseForm(r *Request) error {
+	if r.form.parsed {
+		return r.form.err
+	}
+
+	r.form.parsed = true
+	r.form.err = parseForm(r)
+
+	return r.form.err
+}
+
+func parseForm(r *Request) error {
+	if r.parameters[placing.InForm] == nil {
+		r.parameters[placing.InForm] = make(map[string]Parameter)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(r.request.Header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNotForm, err)
+	}
+
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		if err := readBody(r); err != nil {
+			return err
+		}
+
+		if len(r.body.raw) == 0 {
+			return nil
+		}
+
+		values, err := url.ParseQuery(r.body.raw[0])
+		if err != nil {
+			return fmt.Errorf("can't parse form: %w", err)
+		}
+
+		for key, value := range values {
+			if r.expectsField(key) {
+				r.addFormValue(key, value...)
+			}
+		}
+
+		return nil
+	case "multipart/form-data":
+		if params["boundary"] == "" {
+			return fmt.Errorf("%w: no multipart boundary", ErrNotForm)
+		}
+
+		return parseMultipart(r, params["boundary"])
+	default:
+		return fmt.Errorf("%w: got '%s'", ErrNotForm, mediaType)
+	}
+}
+
+func parseMultipart(r *Request, boundary string) error {
+	if r.request.Body == nil {
+		return nil
+	}
+
+	defer r.request.Body.Close()
+
+	body, err := OpenBody(r)
+	if err != nil {
+		return err
+	}
+
+	var reader = multipart.NewReader(body, boundary)
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return bodyError(err)
+		}
+
+		var name = part.FormName()
+
+		if upload, ok := r.form.uploads[name]; ok {
+			if r.form.uploaded++; r.form.uploaded > maxFormFiles {
+				err = fmt.Errorf("%w: form has more than %d files", ErrBodyTooLarge, maxFormFiles)
+			} else {
+				err = r.saveFile(part, upload)
+			}
+		} else if part.FileName() == "" && r.expectsField(name) {
+			err = r.readFormValue(part)
+		}
+
+		// Closing part discards its unread content, so skipped parts aren't kept in memory.
+		part.Close()
+
+		if err != nil {
+			return bodyError(err)
+		}
+	}
+}
+
+// bodyError - wraps error of reading multipart body, errors of reading body beyond limit are kept as is.
+func bodyError(err error) error {
+	if errors.Is(err, ErrBodyTooLarge) {
+		return err
+	}
+
+	return fmt.Errorf("can't parse multipart form: %w", err)
+}
+
+func (r *Request) expectsField(name string) bool {
+	_, ok := r.form.fields[name]
+
+	return ok
+}
+
+// readFormValue - reads field into memory, all fields together are limited by 'maxFormMemory'.
+func (r *Request) readFormValue(part *multipart.Part) error {
+	// One byte more than left is read to find out that fields are too large.
+	value, err := io.ReadAll(io.LimitReader(part, maxFormMemory-r.form.memory+1))
+	if err != nil {
+		return err
+	}
+
+	r.form.memory += int64(len(value))
+
+	if r.form.memory > maxFormMemory {
+		return fmt.Errorf("%w: form fields exceed %d bytes", ErrBodyTooLarge, maxFormMemory)
+	}
+
+	r.addFormValue(part.FormName(), string(value))
+
+	return nil
+}
+
+func (r *Request) addFormValue(key string, values ...string) {
+	var parameter = r.parameters[placing.InForm][key]
+
+	parameter.Name = key
+	parameter.raw = append(parameter.raw, values...)
+
+	r.parameters[placing.InForm][key] = parameter
+}
+
+// saveFile - streams part to upload's destination.
+// File's violations of upload's settings are saved as validation failures of its field.
+func (r *Request) saveFile(part *multipart.Part, upload Upload) error {
+	var (
+		name = part.FormName()
+		file = File{
+			Field:  name,
+			Header: part.Header,
+		}
+		head = make([]byte, sniffLength)
+	)
+
+	if part.FileName() != "" {
+		file.Filename = path.Base(strings.ReplaceAll(part.FileName(), `\`, "/"))
+	}
+
+	n, err := io.ReadFull(part, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+
+	head = head[:n]
+	file.ContentType = http.DetectContentType(head)
+
+	if !typeAllowed(file.ContentType, upload.AllowedTypes) {
+		r.fileFailure(name, "content_type", fmt.Errorf("file '%s' has type '%s', allowed: %s",
+			name, mediaTypeOf(file.ContentType), strings.Join(upload.AllowedTypes, ", "),
+		))
+
+		return nil
+	}
+
+	writer, err := r.openFile(&file, upload)
+	if err != nil {
+		return err
+	}
+
+	// One byte more than limit is read to find out that file is too large.
+	size, err := io.Copy(writer, io.MultiReader(
+		bytes.NewReader(head),
+		io.LimitReader(part, upload.MaxSize-int64(len(head))+1),
+	))
+
+	if closer, ok := writer.(io.Closer); ok {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	if err == nil && size > upload.MaxSize {
+		err = fmt.Errorf("%w: '%s' exceeds %d bytes", ErrFileTooLarge, name, upload.MaxSize)
+	}
+
+	if err != nil {
+		if file.path != "" {
+			os.Remove(file.path)
+		}
+
+		if errors.Is(err, ErrFileTooLarge) {
+			r.fileFailure(name, "max_size", err)
+
+			return nil
+		}
+
+		return err
+	}
+
+	file.Size = size
+
+	if r.form.files == nil {
+		r.form.files = make(map[string][]*File)
+	}
+
+	r.form.files[name] = append(r.form.files[name], &file)
+
+	return nil
+}
+
+// openFile - returns destination of file: user's writer or temporary file.
+func (r *Request) openFile(file *File, upload Upload) (io.Writer, error) {
+	if upload.Open != nil {
+		return upload.Open(file)
+	}
+
+	temp, err := os.CreateTemp("", "engi-upload-*")
+	if err != nil {
+		return nil, err
+	}
+
+	file.path = temp.Name()
+
+	return temp, nil
+}
+
+func (r *Request) fileFailure(name, rule string, err error) {
+	if r.form.failures == nil {
+		r.form.failures = make(map[string]types.ValidationErrors)
+	}
+
+	r.form.failures[name] = append(r.form.failures[name], Failures(err, LocationForm, name, rule)...)
+}
+
+// FileFailures - returns violations of upload's settings by files sent in field.
+func FileFailures(r *Request, name string) types.ValidationErrors {
+	return r.form.failures[name]
+}
+
+// RemoveFiles - removes temporary files of uploaded files.
+func RemoveFiles(r *Request) {
+	for _, files := range r.form.files {
+		for _, file := range files {
+			if file.path != "" {
+				os.Remove(file.path)
+			}
+		}
+	}
+}
+
+func (r *Request) File(key string) *File {
+	if files := r.form.files[key]; len(files) > 0 {
+		return files[0]
+	}
+
+	return nil
+}
+
+func (r *Request) Files(key string) []*File {
+	return r.form.files[key]
+}
+
+// typeAllowed - reports whether detected content type matches one of allowed types, 'image/*' matches any image.
+func typeAllowed(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	var mediaType = mediaTypeOf(contentType)
+
+	for _, pattern := range allowed {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if pattern == "*/*" || strings.EqualFold(pattern, mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mediaTypeOf - returns content type without parameters, e.g. 'text/plain' for 'text/plain; charset=utf-8'.
+func mediaTypeOf(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
+	return strings.TrimSpace(mediaType)
+}
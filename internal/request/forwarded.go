@@ -0,0 +1,200 @@
+package request
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+const (
+	forwardedHeader      = "Forwarded"
+	forwardedForHeader   = "X-Forwarded-For"
+	forwardedProtoHeader = "X-Forwarded-Proto"
+	forwardedHostHeader  = "X-Forwarded-Host"
+)
+
+// Client - client's connection info resolved through trusted proxies.
+type Client struct {
+	IP     netip.Addr
+	Scheme string
+	Host   string
+}
+
+// hop - single forwarding record added by proxy.
+type hop struct {
+	ip     netip.Addr
+	scheme string
+	host   string
+}
+
+// ResolveClient - resolves client's address, scheme and host.
+// Forwarding headers ('Forwarded' or 'X-Forwarded-*') are used only if request came from trusted proxy,
+// chain of proxies is walked from the nearest one until first untrusted address.
+func ResolveClient(r *http.Request, trusted []netip.Prefix) Client {
+	var client = Client{
+		IP:     remoteAddr(r.RemoteAddr),
+		Scheme: "http",
+		Host:   r.Host,
+	}
+
+	if r.TLS != nil {
+		client.Scheme = "https"
+	}
+
+	if !isTrusted(client.IP, trusted) {
+		return client
+	}
+
+	var hops []hop
+	if values := r.Header.Values(forwardedHeader); len(values) > 0 {
+		hops = parseForwarded(values)
+	} else {
+		hops = parseXForwarded(r.Header)
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !hops[i].ip.IsValid() {
+			break
+		}
+
+		client.IP = hops[i].ip
+
+		if hops[i].scheme != "" {
+			client.Scheme = hops[i].scheme
+		}
+
+		if hops[i].host != "" {
+			client.Host = hops[i].host
+		}
+
+		if !isTrusted(client.IP, trusted) {
+			break
+		}
+	}
+
+	return client
+}
+
+// parseForwarded - parses RFC 7239 'Forwarded' header.
+func parseForwarded(values []string) []hop {
+	var hops []hop
+
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			var result hop
+
+			for _, pair := range strings.Split(element, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					continue
+				}
+
+				value = strings.Trim(value, `"`)
+
+				switch strings.ToLower(key) {
+				case "for":
+					result.ip = remoteAddr(value)
+				case "proto":
+					result.scheme = strings.ToLower(value)
+				case "host":
+					result.host = value
+				}
+			}
+
+			hops = append(hops, result)
+		}
+	}
+
+	return hops
+}
+
+// parseXForwarded - parses 'X-Forwarded-For', 'X-Forwarded-Proto' and 'X-Forwarded-Host' headers.
+// Proto and host lists are matched with addresses by position if their lengths are equal,
+// otherwise the last value applied to every hop.
+func parseXForwarded(header http.Header) []hop {
+	var (
+		addresses = splitValues(header.Values(forwardedForHeader))
+		schemes   = splitValues(header.Values(forwardedProtoHeader))
+		hosts     = splitValues(header.Values(forwardedHostHeader))
+		hops      = make([]hop, len(addresses))
+	)
+
+	for i, address := range addresses {
+		hops[i] = hop{
+			ip:     remoteAddr(address),
+			scheme: strings.ToLower(pick(schemes, i, len(addresses))),
+			host:   pick(hosts, i, len(addresses)),
+		}
+	}
+
+	return hops
+}
+
+func splitValues(values []string) []string {
+	var result []string
+
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+
+	return result
+}
+
+func pick(values []string, index, total int) string {
+	switch len(values) {
+	case 0:
+		return ""
+	case total:
+		return values[index]
+	default:
+		return values[len(values)-1]
+	}
+}
+
+// remoteAddr - parses address with optional port, IPv6 addresses may be in brackets.
+func remoteAddr(address string) netip.Addr {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+
+	ip, err := netip.ParseAddr(strings.Trim(address, "[]"))
+	if err != nil {
+		return netip.Addr{}
+	}
+
+	return ip.Unmap()
+}
+
+// ParsePrefix - parses CIDR (e.g. '10.0.0.0/8') or single IP address as prefix.
+func ParsePrefix(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err == nil {
+		return prefix.Masked(), nil
+	}
+
+	addr, addrErr := netip.ParseAddr(cidr)
+	if addrErr != nil {
+		return netip.Prefix{}, err
+	}
+
+	return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()), nil
+}
+
+func isTrusted(ip netip.Addr, trusted []netip.Prefix) bool {
+	if !ip.IsValid() {
+		return false
+	}
+
+	for _, prefix := range trusted {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package request_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveClient(t *testing.T) {
+	var trusted = []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+	}
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers http.Header
+		want    request.Client
+	}{
+		{
+			name:   "direct request",
+			remote: "203.0.113.5:1234",
+			want:   request.Client{IP: netip.MustParseAddr("203.0.113.5"), Scheme: "http", Host: "example.com"},
+		},
+		{
+			name:   "headers from untrusted address ignored",
+			remote: "203.0.113.5:1234",
+			headers: http.Header{
+				"X-Forwarded-For":   {"198.51.100.1"},
+				"X-Forwarded-Proto": {"https"},
+			},
+			want: request.Client{IP: netip.MustParseAddr("203.0.113.5"), Scheme: "http", Host: "example.com"},
+		},
+		{
+			name:   "x-forwarded through trusted proxies",
+			remote: "10.0.0.1:1234",
+			headers: http.Header{
+				"X-Forwarded-For":   {"198.51.100.7, 198.51.100.1, 10.0.0.2"},
+				"X-Forwarded-Proto": {"https"},
+				"X-Forwarded-Host":  {"api.example.com"},
+			},
+			want: request.Client{IP: netip.MustParseAddr("198.51.100.1"), Scheme: "https", Host: "api.example.com"},
+		},
+		{
+			name:   "forwarded header",
+			remote: "10.0.0.1:1234",
+			headers: http.Header{
+				"Forwarded":       {`for="[2001:db8::1]:4711";proto=https;host=api.example.com, for=10.0.0.3`},
+				"X-Forwarded-For": {"198.51.100.1"},
+			},
+			want: request.Client{IP: netip.MustParseAddr("2001:db8::1"), Scheme: "https", Host: "api.example.com"},
+		},
+		{
+			name:   "unknown hop stops resolution",
+			remote: "10.0.0.1:1234",
+			headers: http.Header{
+				"Forwarded": {`for=unknown, for=10.0.0.3;proto=https`},
+			},
+			want: request.Client{IP: netip.MustParseAddr("10.0.0.3"), Scheme: "https", Host: "example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r = httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+
+			r.RemoteAddr = tt.remote
+			for key, values := range tt.headers {
+				r.Header[key] = values
+			}
+
+			assert.Equal(t, tt.want, request.ResolveClient(r, trusted))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package request
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidUUID    = errors.New("invalid UUID")
+	ErrInvalidDecimal = errors.New("invalid decimal")
+	ErrInvalidEmail   = errors.New("invalid email")
+	ErrInvalidURL     = errors.New("invalid URL")
+	ErrInvalidBytes   = errors.New("invalid base64 value")
+)
+
+// UUID - universally unique identifier (RFC 4122).
+type UUID [16]byte
+
+// ParseUUID - parses UUID in canonical form 'xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx', case-insensitive.
+func ParseUUID(value string) (UUID, error) {
+	var uuid UUID
+
+	if len(value) != 36 || value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
+		return uuid, fmt.Errorf("%w: '%s'", ErrInvalidUUID, value)
+	}
+
+	if _, err := hex.Decode(uuid[:], []byte(strings.ReplaceAll(value, "-", ""))); err != nil {
+		return uuid, fmt.Errorf("%w: '%s'", ErrInvalidUUID, value)
+	}
+
+	return uuid, nil
+}
+
+// String - returns UUID in canonical lowercase form.
+func (uuid UUID) String() string {
+	var text = hex.EncodeToString(uuid[:])
+
+	return text[:8] + "-" + text[8:12] + "-" + text[12:16] + "-" + text[16:20] + "-" + text[20:]
+}
+
+func (uuid UUID) MarshalText() ([]byte, error) {
+	return []byte(uuid.String()), nil
+}
+
+func (uuid *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*uuid = parsed
+
+	return nil
+}
+
+// decimalRegexp - decimal number in plain notation, without exponent, base prefixes and digit separators.
+var decimalRegexp = regexp.MustCompile(`^(\+|-)?\d+(\.\d+)?$`)
+
+// Decimal - arbitrary precision decimal number kept in its textual form, so no precision is lost.
+type Decimal string
+
+// ParseDecimal - parses decimal number like '-12.345'.
+func ParseDecimal(value string) (Decimal, error) {
+	if !decimalRegexp.MatchString(value) {
+		return "", fmt.Errorf("%w: '%s'", ErrInvalidDecimal, value)
+	}
+
+	return Decimal(strings.TrimPrefix(value, "+")), nil
+}
+
+// Rat - returns decimal as exact rational number, nil for empty decimal.
+func (decimal Decimal) Rat() *big.Rat {
+	result, ok := new(big.Rat).SetString(string(decimal))
+	if !ok {
+		return nil
+	}
+
+	return result
+}
+
+// Float64 - returns nearest floating point number.
+func (decimal Decimal) Float64() float64 {
+	var rat = decimal.Rat()
+	if rat == nil {
+		return 0
+	}
+
+	result, _ := rat.Float64()
+
+	return result
+}
+
+func (decimal Decimal) String() string {
+	return string(decimal)
+}
+
+// ParseEmail - parses bare email address like 'user@example.com' (display names aren't allowed).
+func ParseEmail(value string) (string, error) {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		return "", fmt.Errorf("%w: '%s'", ErrInvalidEmail, value)
+	}
+
+	return address.Address, nil
+}
+
+// ParseURL - parses absolute URL with scheme and host.
+func ParseURL(value string) (*url.URL, error) {
+	result, err := url.Parse(value)
+	if err != nil || !result.IsAbs() || result.Host == "" {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidURL, value)
+	}
+
+	return result, nil
+}
+
+// ParseBytes - decodes base64 value, both standard and URL-safe alphabets with or without padding accepted.
+func ParseBytes(value string) ([]byte, error) {
+	for _, encoding := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	} {
+		if result, err := encoding.DecodeString(value); err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrInvalidBytes, value)
+}
@@ -2,12 +2,17 @@ package request
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/kliuchnikovv/engi/definition/codec"
 	"github.com/kliuchnikovv/engi/definition/parameter/placing"
 )
 
@@ -19,7 +24,20 @@ const (
 )
 
 type (
-	Option          func(*Parameter) error
+	// Option - parameter's option: validator or setting of parameter's extraction.
+	Option interface {
+		// Validate - checks extracted parameter.
+		Validate(*Parameter) error
+	}
+
+	// Validator - function validating extracted parameter.
+	Validator func(*Parameter) error
+
+	// Checker - option checking on route registration that it can validate values of type.
+	Checker interface {
+		Check(reflect.Type) error
+	}
+
 	ParamsValidator interface {
 		Validate(param string) error
 	}
@@ -36,6 +54,9 @@ type (
 		// Body - returns request body.
 		// Body must be requested by 'api.Body(pointer)' or 'api.CustomBody(unmarshaler, pointer)'.
 		Body() interface{}
+		// Stream - returns reader of body requested by 'parameter.StreamBody', body is read while handler reads it.
+		// Reading beyond limit of body's size returns error wrapping 'engi.ErrBodyTooLarge'.
+		Stream() io.Reader
 		// Bool - returns boolean parameter.
 		// Mandatory parameter should be requested by 'api.Bool'.
 		// Otherwise, parameter will be obtained by key and its value will be checked for truth.
@@ -49,16 +70,96 @@ type (
 		// Otherwise, parameter will be obtained by key and its value will be converted to float64.
 		Float(value string, place placing.Placing) float64
 		// String - returns String parameter.
-		// Mandatory parameter should be requested by 'api.String'.
+		// Mandatory parameter should be requested by 'api.String' ('api.Enum' and 'api.Email' too).
 		// Otherwise, parameter will be obtained by key.
 		String(value string, place placing.Placing) string
 		// Time - returns date-time parameter.
 		// Mandatory parameter should be requested by 'api.Time'.
 		// Otherwise, parameter will be obtained by key and its value will be converted to time using 'layout'.
 		Time(key string, layout string, paramPlacing placing.Placing) time.Time
+		// Uint - returns unsigned integer parameter.
+		// Mandatory parameter should be requested by 'api.Uint'.
+		// Otherwise, parameter will be obtained by key and its value will be converted to uint64.
+		Uint(key string, paramPlacing placing.Placing) uint64
+		// Duration - returns duration parameter like '1h30m'.
+		// Mandatory parameter should be requested by 'api.Duration'.
+		// Otherwise, parameter will be obtained by key and its value will be converted to time.Duration.
+		Duration(key string, paramPlacing placing.Placing) time.Duration
+		// UUID - returns UUID parameter.
+		// Mandatory parameter should be requested by 'api.UUID'.
+		// Otherwise, parameter will be obtained by key and its value will be converted to UUID.
+		UUID(key string, paramPlacing placing.Placing) UUID
+		// Decimal - returns arbitrary precision decimal parameter.
+		// Mandatory parameter should be requested by 'api.Decimal'.
+		// Otherwise, parameter will be obtained by key and its value will be converted to Decimal.
+		Decimal(key string, paramPlacing placing.Placing) Decimal
+		// IP - returns IP address parameter.
+		// Mandatory parameter should be requested by 'api.IP'.
+		// Otherwise, parameter will be obtained by key and its value will be converted to netip.Addr.
+		IP(key string, paramPlacing placing.Placing) netip.Addr
+		// CIDR - returns network parameter like '10.0.0.0/8'.
+		// Mandatory parameter should be requested by 'api.CIDR'.
+		// Otherwise, parameter will be obtained by key and its value will be converted to netip.Prefix.
+		CIDR(key string, paramPlacing placing.Placing) netip.Prefix
+		// URL - returns absolute URL parameter.
+		// Mandatory parameter should be requested by 'api.URL'.
+		// Otherwise, parameter will be obtained by key and its value will be converted to *url.URL.
+		URL(key string, paramPlacing placing.Placing) *url.URL
+		// Bytes - returns base64 encoded parameter decoded to bytes.
+		// Mandatory parameter should be requested by 'api.Bytes'.
+		// Otherwise, parameter will be obtained by key and its value will be decoded.
+		Bytes(key string, paramPlacing placing.Placing) []byte
+		// Bools - returns multi-value boolean parameter.
+		// Parameter should be requested by 'api.Bools'.
+		// Otherwise, all values sent by key will be checked for truth.
+		Bools(key string, paramPlacing placing.Placing) []bool
+		// Integers - returns multi-value integer parameter.
+		// Parameter should be requested by 'api.Integers'.
+		// Otherwise, all values sent by key will be converted to int64.
+		Integers(key string, paramPlacing placing.Placing) []int64
+		// Floats - returns multi-value floating point number parameter.
+		// Parameter should be requested by 'api.Floats'.
+		// Otherwise, all values sent by key will be converted to float64.
+		Floats(key string, paramPlacing placing.Placing) []float64
+		// Strings - returns multi-value string parameter.
+		// Parameter should be requested by 'api.Strings'.
+		// Otherwise, all values sent by key will be returned.
+		Strings(key string, paramPlacing placing.Placing) []string
+		// Times - returns multi-value date-time parameter.
+		// Parameter should be requested by 'api.Times'.
+		// Otherwise, all values sent by key will be converted to time using 'layout'.
+		Times(key string, layout string, paramPlacing placing.Placing) []time.Time
+		// Value - returns converted value of parameter requested by middleware, nil otherwise.
+		// Use 'engi.Param' to get typed value.
+		Value(key string, paramPlacing placing.Placing) any
+		// Has - reports whether parameter's value was sent by client.
+		// Optional parameter that wasn't sent has default value (or zero value if no default set).
+		Has(key string, paramPlacing placing.Placing) bool
+		// File - returns file uploaded in form's field by 'key', nil if file wasn't requested or sent.
+		// If several files were sent, first one returned.
+		File(key string) *File
+		// Files - returns all files uploaded in form's field by 'key'.
+		Files(key string) []*File
+		// CSRFToken - returns CSRF token issued for this request.
+		// Token is set only if route protected by 'csrf' middleware, otherwise empty string returned.
+		CSRFToken() string
+		// Principal - returns identity of authorized client (e.g. username or truncated hash of token).
+		// Principal is set only if route protected by 'auth' middleware, otherwise empty string returned.
+		Principal() string
+		// ClientIP - returns client's IP address.
+		// Forwarding headers are taken into account only if request came through trusted proxy.
+		ClientIP() netip.Addr
+		// Scheme - returns scheme ('http' or 'https') used by client.
+		Scheme() string
+		// Host - returns host requested by client.
+		Host() string
 	}
 )
 
+func (validator Validator) Validate(p *Parameter) error {
+	return validator(p)
+}
+
 type Parameter struct {
 	raw          []string
 	Parsed       interface{}
@@ -75,6 +176,16 @@ type Request struct {
 	body       Parameter
 	parameters map[placing.Placing]map[string]Parameter
 
+	client      Client
+	csrfToken   string
+	principal   string
+	maxBodySize int64
+	codecs      *codec.Registry
+	form        form
+	stream      io.Reader
+	closers     []io.Closer
+	logger      *slog.Logger
+
 	Description string
 }
 
@@ -94,6 +205,7 @@ func New(request *http.Request) *Request {
 		r          = Request{
 			request:    request,
 			parameters: make(map[placing.Placing]map[string]Parameter),
+			client:     ResolveClient(request, nil),
 		}
 	)
 
@@ -128,12 +240,8 @@ func New(request *http.Request) *Request {
 }
 
 func (r *Request) Bool(key string, paramPlacing placing.Placing) bool {
-	if r.isMandatoryParam(key, paramPlacing) {
-		if result, ok := r.parameters[paramPlacing][key].Parsed.(bool); ok {
-			return result
-		}
-
-		panic(fmt.Errorf("conversion parameter to bool failed (key: %s)", key))
+	if result, ok := requested[bool](r, key, paramPlacing); ok {
+		return result
 	}
 
 	result, _ := strconv.ParseBool(r.String(key, paramPlacing))
@@ -142,12 +250,8 @@ func (r *Request) Bool(key string, paramPlacing placing.Placing) bool {
 }
 
 func (r *Request) Integer(key string, paramPlacing placing.Placing) int64 {
-	if r.isMandatoryParam(key, paramPlacing) {
-		if result, ok := r.parameters[paramPlacing][key].Parsed.(int64); ok {
-			return result
-		}
-
-		panic(fmt.Errorf("conversion parameter to int64 failed (key: %s)", key))
+	if result, ok := requested[int64](r, key, paramPlacing); ok {
+		return result
 	}
 
 	result, _ := strconv.ParseInt(r.String(key, paramPlacing), IntBase, BitSize)
@@ -156,12 +260,8 @@ func (r *Request) Integer(key string, paramPlacing placing.Placing) int64 {
 }
 
 func (r *Request) Float(key string, paramPlacing placing.Placing) float64 {
-	if r.isMandatoryParam(key, paramPlacing) {
-		if result, ok := r.parameters[paramPlacing][key].Parsed.(float64); ok {
-			return result
-		}
-
-		panic(fmt.Errorf("conversion parameter to float64 failed (key: %s)", key))
+	if result, ok := requested[float64](r, key, paramPlacing); ok {
+		return result
 	}
 
 	result, _ := strconv.ParseFloat(r.String(key, paramPlacing), BitSize)
@@ -170,24 +270,16 @@ func (r *Request) Float(key string, paramPlacing placing.Placing) float64 {
 }
 
 func (r *Request) String(key string, paramPlacing placing.Placing) string {
-	if r.isMandatoryParam(key, paramPlacing) {
-		if result, ok := r.parameters[paramPlacing][key].Parsed.(string); ok {
-			return result
-		}
-
-		panic(fmt.Errorf("conversion parameter to string failed (key: %s)", key))
+	if result, ok := requested[string](r, key, paramPlacing); ok {
+		return result
 	}
 
 	return r.GetParameter(key, paramPlacing)
 }
 
 func (r *Request) Time(key, layout string, paramPlacing placing.Placing) time.Time {
-	if r.isMandatoryParam(key, paramPlacing) {
-		if result, ok := r.parameters[paramPlacing][key].Parsed.(time.Time); ok {
-			return result
-		}
-
-		panic(fmt.Errorf("conversion parameter to time failed (key: %s)", key))
+	if result, ok := requested[time.Time](r, key, paramPlacing); ok {
+		return result
 	}
 
 	result, _ := time.Parse(layout, r.String(key, paramPlacing))
@@ -195,6 +287,161 @@ func (r *Request) Time(key, layout string, paramPlacing placing.Placing) time.Ti
 	return result
 }
 
+func (r *Request) Uint(key string, paramPlacing placing.Placing) uint64 {
+	return requestedValue(r, key, paramPlacing, func(value string) (uint64, error) {
+		return strconv.ParseUint(value, IntBase, BitSize)
+	})
+}
+
+func (r *Request) Duration(key string, paramPlacing placing.Placing) time.Duration {
+	return requestedValue(r, key, paramPlacing, time.ParseDuration)
+}
+
+func (r *Request) UUID(key string, paramPlacing placing.Placing) UUID {
+	return requestedValue(r, key, paramPlacing, ParseUUID)
+}
+
+func (r *Request) Decimal(key string, paramPlacing placing.Placing) Decimal {
+	return requestedValue(r, key, paramPlacing, ParseDecimal)
+}
+
+func (r *Request) IP(key string, paramPlacing placing.Placing) netip.Addr {
+	return requestedValue(r, key, paramPlacing, netip.ParseAddr)
+}
+
+func (r *Request) CIDR(key string, paramPlacing placing.Placing) netip.Prefix {
+	return requestedValue(r, key, paramPlacing, netip.ParsePrefix)
+}
+
+func (r *Request) URL(key string, paramPlacing placing.Placing) *url.URL {
+	return requestedValue(r, key, paramPlacing, ParseURL)
+}
+
+func (r *Request) Bytes(key string, paramPlacing placing.Placing) []byte {
+	return requestedValue(r, key, paramPlacing, ParseBytes)
+}
+
+// requestedValue - returns value of parameter requested by middleware.
+// If parameter wasn't requested, sent value is converted using 'parse',
+// zero value returned if it can't be converted.
+func requestedValue[T any](
+	r *Request,
+	key string,
+	paramPlacing placing.Placing,
+	parse func(string) (T, error),
+) T {
+	if result, ok := requested[T](r, key, paramPlacing); ok {
+		return result
+	}
+
+	result, err := parse(r.String(key, paramPlacing))
+	if err != nil {
+		var zero T
+
+		return zero
+	}
+
+	return result
+}
+
+func (r *Request) Bools(key string, paramPlacing placing.Placing) []bool {
+	return requestedValues(r, key, paramPlacing, strconv.ParseBool)
+}
+
+func (r *Request) Integers(key string, paramPlacing placing.Placing) []int64 {
+	return requestedValues(r, key, paramPlacing, func(value string) (int64, error) {
+		return strconv.ParseInt(value, IntBase, BitSize)
+	})
+}
+
+func (r *Request) Floats(key string, paramPlacing placing.Placing) []float64 {
+	return requestedValues(r, key, paramPlacing, func(value string) (float64, error) {
+		return strconv.ParseFloat(value, BitSize)
+	})
+}
+
+func (r *Request) Strings(key string, paramPlacing placing.Placing) []string {
+	return requestedValues(r, key, paramPlacing, func(value string) (string, error) {
+		return value, nil
+	})
+}
+
+func (r *Request) Times(key, layout string, paramPlacing placing.Placing) []time.Time {
+	return requestedValues(r, key, paramPlacing, func(value string) (time.Time, error) {
+		return time.Parse(layout, value)
+	})
+}
+
+// requestedValues - returns values of multi-value parameter requested by middleware.
+// If parameter wasn't requested, all sent values are converted using 'parse',
+// values that can't be converted are replaced with zero value.
+func requestedValues[T any](
+	r *Request,
+	key string,
+	paramPlacing placing.Placing,
+	parse func(string) (T, error),
+) []T {
+	if result, ok := requested[[]T](r, key, paramPlacing); ok {
+		return result
+	}
+
+	var (
+		param, _ = r.lookup(key, paramPlacing)
+		result   = make([]T, len(param.raw))
+	)
+
+	for i, value := range param.raw {
+		result[i], _ = parse(value)
+	}
+
+	return result
+}
+
+func (r *Request) Value(key string, paramPlacing placing.Placing) any {
+	if !r.isMandatoryParam(key, paramPlacing) {
+		return nil
+	}
+
+	param, _ := r.lookup(key, paramPlacing)
+
+	return param.Parsed
+}
+
+func (r *Request) Has(key string, paramPlacing placing.Placing) bool {
+	return len(r.GetParameter(key, paramPlacing)) != 0
+}
+
+// requested - returns value of parameter requested by middleware.
+// Returns false if parameter wasn't requested or was requested with another type, the latter is logged.
+func requested[T any](r *Request, key string, paramPlacing placing.Placing) (T, bool) {
+	var zero T
+
+	if !r.isMandatoryParam(key, paramPlacing) {
+		return zero, false
+	}
+
+	param, _ := r.lookup(key, paramPlacing)
+
+	var parsed = param.Parsed
+
+	// Optional parameter without default value.
+	if parsed == nil {
+		return zero, true
+	}
+
+	result, ok := parsed.(T)
+	if !ok && r.logger != nil {
+		r.logger.Warn("parameter was requested with another type",
+			slog.String("key", key),
+			slog.String("placing", string(paramPlacing)),
+			slog.String("requested", fmt.Sprintf("%T", parsed)),
+			slog.String("got_as", fmt.Sprintf("%T", zero)),
+		)
+	}
+
+	return result, ok
+}
+
 func (r *Request) Parameters() map[placing.Placing]map[string]string {
 	var parameters = make(map[placing.Placing]map[string]string)
 
@@ -214,27 +461,38 @@ func (r *Request) Body() interface{} {
 }
 
 func (r *Request) isMandatoryParam(key string, paramPlacing placing.Placing) bool {
-	switch paramPlacing {
-	case placing.InPath:
-		return true
-	case placing.InQuery:
-		param, ok := r.parameters[paramPlacing][key]
-		return ok && param.wasRequested
-	default:
-		return false
+	param, ok := r.lookup(key, paramPlacing)
+
+	return ok && param.wasRequested
+}
+
+// lookup - returns parameter by key, header names are matched case-insensitively.
+func (r *Request) lookup(key string, paramPlacing placing.Placing) (Parameter, bool) {
+	param, ok := r.parameters[paramPlacing][canonicalKey(key, paramPlacing)]
+
+	return param, ok
+}
+
+// canonicalKey - returns key parameter stored by: headers are stored in canonical form (e.g. 'X-Page').
+func canonicalKey(key string, paramPlacing placing.Placing) string {
+	if paramPlacing == placing.InHeader {
+		return http.CanonicalHeaderKey(key)
 	}
+
+	return key
 }
 
 func (r *Request) GetParameter(key string, paramPlacing placing.Placing) string {
-	if _, ok := r.parameters[paramPlacing][key]; !ok {
+	param, ok := r.lookup(key, paramPlacing)
+	if !ok || len(param.raw) == 0 {
 		return ""
 	}
 
-	if len(r.parameters[paramPlacing][key].raw) > 1 {
-		return strings.Join(r.parameters[paramPlacing][key].raw, ", ")
+	if len(param.raw) > 1 {
+		return strings.Join(param.raw, ", ")
 	}
 
-	return r.parameters[paramPlacing][key].raw[0]
+	return param.raw[0]
 }
 
 func (r *Request) GetRequest() *http.Request {
@@ -257,6 +515,26 @@ func (r *Request) Headers() map[string][]string {
 	return r.request.Header
 }
 
+func (r *Request) CSRFToken() string {
+	return r.csrfToken
+}
+
+func (r *Request) Principal() string {
+	return r.principal
+}
+
+func (r *Request) ClientIP() netip.Addr {
+	return r.client.IP
+}
+
+func (r *Request) Scheme() string {
+	return r.client.Scheme
+}
+
+func (r *Request) Host() string {
+	return r.client.Host
+}
+
 // func (r *Request) UpdateParameter(
 // 	response *internalResponse.Response,
 // 	key string,
@@ -0,0 +1,204 @@
+package request
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrUnsupportedEncoding - body is compressed with 'Content-Encoding' that can't be decoded.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// maxDecoderWindow - limit of zstd window, bodies compressed with bigger one are rejected (RFC 8878 recommends 8MB for HTTP).
+const maxDecoderWindow = 8 << 20
+
+// maxDecodedSize - limit of decoded body when 'SetMaxBodySize' isn't set, so small compressed body can't expand without bound.
+const maxDecodedSize = 32 << 20
+
+// OpenBody - returns reader of body decoded according to 'Content-Encoding' ('gzip' or 'zstd').
+// Both sent and decoded bytes are limited by 'SetMaxBodySize', reading beyond limit returns error wrapping 'ErrBodyTooLarge'.
+// Without limit decoded bytes are still limited by 32MB.
+// Decoders are released by 'Close'.
+func OpenBody(r *Request) (io.Reader, error) {
+	var (
+		body  io.Reader = r.request.Body
+		limit           = r.maxBodySize
+	)
+
+	if body == nil {
+		return http.NoBody, nil
+	}
+
+	if limit > 0 {
+		if r.request.ContentLength > limit {
+			return nil, fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)
+		}
+
+		body = http.MaxBytesReader(nil, r.request.Body, limit)
+	}
+
+	// Encodings are listed in order they were applied.
+	var encodings = r.request.Header.Values("Content-Encoding")
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var list = strings.Split(encodings[i], ",")
+
+		for j := len(list) - 1; j >= 0; j-- {
+			decoded, err := r.decode(body, strings.ToLower(strings.TrimSpace(list[j])))
+			if err != nil {
+				return nil, err
+			}
+
+			body = decoded
+		}
+	}
+
+	if len(encodings) > 0 {
+		var decodedLimit = limit
+		if decodedLimit <= 0 {
+			decodedLimit = maxDecodedSize
+		}
+
+		body = http.MaxBytesReader(nil, io.NopCloser(body), decodedLimit)
+	}
+
+	return limitedReader{body}, nil
+}
+
+// decode - wraps body with decoder of 'encoding'.
+func (r *Request) decode(body io.Reader, encoding string) (io.Reader, error) {
+	switch encoding {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		reader, err := gzip.NewReader(limitedReader{body})
+		if err != nil && !errors.Is(err, ErrBodyTooLarge) {
+			return nil, fmt.Errorf("can't decode gzip body: %w", err)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return reader, nil
+	case "zstd":
+		// Decoder's memory shouldn't depend on client: one goroutine, limited window and buffers.
+		decoder, err := zstd.NewReader(body,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxWindow(maxDecoderWindow),
+			zstd.WithDecoderLowmem(true),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		r.closers = append(r.closers, decoder.IOReadCloser())
+
+		return decoder, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s', supported: gzip, zstd", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+// limitedReader - converts errors of reading body beyond limit to 'ErrBodyTooLarge'.
+type limitedReader struct {
+	io.Reader
+}
+
+func (reader limitedReader) Read(p []byte) (int, error) {
+	n, err := reader.Reader.Read(p)
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		err = fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, tooLarge.Limit)
+	}
+
+	return n, err
+}
+
+// SetStream - sets reader of body requested by 'parameter.StreamBody'.
+func SetStream(r *Request, stream io.Reader) {
+	r.stream = stream
+}
+
+func (r *Request) Stream() io.Reader {
+	if r.stream == nil {
+		return http.NoBody
+	}
+
+	return r.stream
+}
+
+// Close - releases request's resources: temporary files of uploaded files and body decoders.
+func Close(r *Request) {
+	RemoveFiles(r)
+
+	for _, closer := range r.closers {
+		closer.Close()
+	}
+}
+
+// Items - decoder of newline-delimited JSON body, items are decoded one at a time while they're iterated.
+type Items[T any] struct {
+	decoder *json.Decoder
+	options []Option
+}
+
+// NewItems - creates decoder of items read from 'reader', every item is validated by 'options'.
+func NewItems[T any](reader io.Reader, options []Option) *Items[T] {
+	return &Items[T]{
+		decoder: json.NewDecoder(reader),
+		options: options,
+	}
+}
+
+// All - returns iterator over items of body.
+// Body is read only when next item is requested, so slow consumer slows down client.
+// Invalid items are yielded with validation errors, iteration stops on malformed JSON,
+// error of reading body (e.g. 'ErrBodyTooLarge') or cancellation of 'ctx'.
+func (items *Items[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for line := 1; ; line++ {
+			var item T
+
+			if err := ctx.Err(); err != nil {
+				yield(item, err)
+
+				return
+			}
+
+			err := items.decoder.Decode(&item)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if err != nil {
+				yield(item, fmt.Errorf("item %d: %w", line, err))
+
+				return
+			}
+
+			var parameter = Parameter{Parsed: &item}
+
+			if failures := Validate(&parameter, LocationBody, items.options); len(failures) > 0 {
+				if !yield(item, fmt.Errorf("item %d: %w", line, failures)) {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package request_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemsCancellation(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		items       = request.NewItems[int](strings.NewReader("1\n2\n3\n"), nil)
+		result      []int
+		failure     error
+	)
+	defer cancel()
+
+	for item, err := range items.All(ctx) {
+		if err != nil {
+			failure = err
+
+			continue
+		}
+
+		result = append(result, item)
+
+		if item == 2 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, []int{1, 2}, result)
+	assert.ErrorIs(t, failure, context.Canceled)
+}
@@ -1,17 +1,20 @@
 package request
 
 import (
-	"encoding/json"
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 
+	"github.com/kliuchnikovv/engi/definition/codec"
 	"github.com/kliuchnikovv/engi/definition/parameter/placing"
 	"github.com/kliuchnikovv/engi/internal/types"
 )
 
+// ErrBodyTooLarge - body exceeds limit set by 'SetMaxBodySize'.
+var ErrBodyTooLarge = errors.New("request body too large")
+
 // ExtractParam - extracting parameter from context, calls middleware and saves to 'context.parameters[from][key]'.
 // After this parameter can be retrieved from context using 'context.Query' methods.
 func ExtractParam(
@@ -21,14 +24,20 @@ func ExtractParam(
 	configs []Option,
 	convert func(string) (interface{}, error),
 ) error {
-	var param = request.GetParameter(key, paramPlacing)
+	key = canonicalKey(key, paramPlacing)
+
+	var (
+		location = string(paramPlacing)
+		param    = request.GetParameter(key, paramPlacing)
+	)
+
 	if len(param) == 0 {
-		return fmt.Errorf("parameter not found: %s", key)
+		return Failures(fmt.Errorf("parameter not found: %s", key), location, key, "required")
 	}
 
 	result, err := convert(param)
 	if err != nil {
-		return fmt.Errorf("can't convert parameter '%s': %s", key, err)
+		return Failures(fmt.Errorf("can't convert parameter '%s': %s", key, err), location, key, "type")
 	}
 
 	var parameter = request.parameters[paramPlacing][key]
@@ -41,10 +50,8 @@ func ExtractParam(
 	}
 	parameter = request.parameters[paramPlacing][key]
 
-	for _, config := range configs {
-		if err := config(&parameter); err != nil {
-			return err
-		}
+	if failures := Validate(&parameter, location, configs); len(failures) > 0 {
+		return failures
 	}
 
 	parameter.Name = key
@@ -65,83 +72,67 @@ func ExtractBody(
 		}
 
 		if len(request.body.raw) == 0 {
-			return fmt.Errorf("no body found after reading")
+			return Failures(fmt.Errorf("no body found after reading"), LocationBody, "", "required")
 		}
 	}
 
 	if err := unmarshaler([]byte(request.body.raw[0]), pointer); err != nil {
-		return err
+		return Failures(err, LocationBody, "", "type")
 	}
 
-	for _, config := range configs {
-		if err := config(&request.body); err != nil {
-			return err
-		}
+	request.body.Parsed = pointer
+	request.body.wasRequested = true
+
+	if failures := Validate(&request.body, LocationBody, configs); len(failures) > 0 {
+		return failures
 	}
 
 	return nil
 }
 
+// GetUnmarshaler - returns unmarshaler of codec registered for request's 'Content-Type',
+// error wraps 'codec.ErrUnsupportedMediaType' if there is no such codec.
 func GetUnmarshaler(request *Request) (types.Unmarshaler, error) {
-	var (
-		contentType = request.request.Header.Get("Content-type")
-		unmarshal   types.Unmarshaler
-	)
-
-	switch contentType {
-	case "application/json":
-		unmarshal = json.Unmarshal
-	case "application/xml":
-		unmarshal = xml.Unmarshal
-	case "text/plain":
-		unmarshal = func(b []byte, i interface{}) error {
-			typed, ok := i.(*string)
-			if !ok {
-				panic("not implemented")
-				// return nil, err//response.AsError(http.StatusInternalServerError, "pointer must be of type '*string'")
-			}
-
-			*typed = string(b)
-
-			return nil
-		}
-	default:
-		panic("not implemented")
-		// return nil, response.AsError(http.StatusBadRequest, "content-type not supported: %s", contentType)
+	var codecs = request.codecs
+	if codecs == nil {
+		codecs = codec.Default()
 	}
 
-	return func(bytes []byte, pointer interface{}) error {
-		if err := unmarshal(bytes, pointer); err != nil {
-			panic("not implemented")
-			// return response.AsError(http.StatusInternalServerError, "unmarshaling body failed: %s", err.Error())
-		}
-
-		request.body.wasRequested = true
-		request.body.Parsed = pointer
+	codec, err := codecs.Lookup(request.request.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
 
-		return nil
-	}, nil
+	return codec.Unmarshal, nil
 }
 
+// readBody - reads whole decoded body, returns 'ErrBodyTooLarge' if body exceeds limit set by 'SetMaxBodySize'.
 func readBody(request *Request) error {
+	if request.request.Body == nil {
+		return nil
+	}
+
 	defer request.request.Body.Close()
 
-	bytes, err := io.ReadAll(request.request.Body)
-	if err != nil && !errors.Is(err, http.ErrBodyReadAfterClose) {
-		panic("not implemented")
-		// return response.AsError(http.StatusInternalServerError, "reading body failed: %s", err.Error())
+	body, err := OpenBody(request)
+	if err != nil {
+		return err
 	}
 
-	if len(bytes) != 0 {
-		request.body.raw = []string{string(bytes)}
+	bytes, err := io.ReadAll(body)
+
+	switch {
+	case errors.Is(err, ErrBodyTooLarge):
+		return err
+	case err != nil && !errors.Is(err, http.ErrBodyReadAfterClose):
+		return fmt.Errorf("reading body failed: %w", err)
 	}
 
-	if len(request.body.raw) == 0 {
-		panic("not implemented")
-		// return response.AsError(http.StatusBadRequest, "no required body provided")
+	if len(bytes) != 0 {
+		request.body.raw = []string{string(bytes)}
 	}
 
-	return err
+	return nil
 }
 
 func SetParameters(r *Request, place placing.Placing, params map[string]string) {
@@ -165,3 +156,65 @@ func SetParameters(r *Request, place placing.Placing, params map[string]string)
 		}
 	}
 }
+
+func SetCSRFToken(r *Request, token string) {
+	r.csrfToken = token
+}
+
+func SetPrincipal(r *Request, principal string) {
+	r.principal = principal
+}
+
+func SetClient(r *Request, client Client) {
+	r.client = client
+}
+
+// SetCodecs - sets codecs used to decode body.
+func SetCodecs(r *Request, codecs *codec.Registry) {
+	r.codecs = codecs
+}
+
+// SetLogger - sets logger reporting misuse of request, e.g. getting parameter as type it wasn't requested with.
+func SetLogger(r *Request, logger *slog.Logger) {
+	r.logger = logger
+}
+
+// SetMaxBodySize - limits size of body in bytes, zero or negative size means no limit.
+func SetMaxBodySize(r *Request, size int64) {
+	r.maxBodySize = size
+}
+
+// MaxBodySize - returns limit of body's size in bytes set by 'SetMaxBodySize'.
+func MaxBodySize(r *Request) int64 {
+	return r.maxBodySize
+}
+
+// SetBody - sets parsed body, e.g. decoder of streamed items.
+func SetBody(r *Request, value interface{}) {
+	r.body.Parsed = value
+	r.body.wasRequested = true
+}
+
+// SetParsed - sets parsed value of parameter, e.g. default value of optional parameter that wasn't sent.
+func SetParsed(r *Request, key string, place placing.Placing, value interface{}) {
+	key = canonicalKey(key, place)
+
+	if r.parameters[place] == nil {
+		r.parameters[place] = make(map[string]Parameter)
+	}
+
+	var parameter = r.parameters[place][key]
+
+	parameter.Name = key
+	parameter.Parsed = value
+	parameter.wasRequested = true
+
+	r.parameters[place][key] = parameter
+}
+
+// Values - returns all values of parameter sent by client.
+func Values(r *Request, key string, place placing.Placing) []string {
+	param, _ := r.lookup(key, place)
+
+	return param.raw
+}
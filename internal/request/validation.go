@@ -0,0 +1,95 @@
+package request
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/kliuchnikovv/engi/internal/types"
+)
+
+const (
+	// LocationBody - location of body's validation failures.
+	LocationBody = "body"
+	// LocationRequest - location of failures of validators checking whole request (e.g. several parameters).
+	LocationRequest = "request"
+)
+
+// Rule - validator reporting its failures under rule 'name' (e.g. 'lt').
+func Rule(name string, validator Validator) Validator {
+	return func(p *Parameter) error {
+		var err = validator(p)
+		if err == nil {
+			return nil
+		}
+
+		var (
+			failure  *types.ValidationError
+			failures types.ValidationErrors
+		)
+
+		if errors.As(err, &failure) || errors.As(err, &failures) {
+			return err
+		}
+
+		return &types.ValidationError{Rule: name, Message: err.Error()}
+	}
+}
+
+// Validate - runs all validators of parameter and collects their failures.
+func Validate(p *Parameter, location string, options []Option) types.ValidationErrors {
+	var result types.ValidationErrors
+
+	for _, option := range options {
+		if err := option.Validate(p); err != nil {
+			result = append(result, Failures(err, location, p.Name, "custom")...)
+		}
+	}
+
+	return result
+}
+
+// Failures - converts error to validation failures, missing location, field and rule are filled with provided ones.
+func Failures(err error, location, field, rule string) types.ValidationErrors {
+	var (
+		failure  *types.ValidationError
+		failures types.ValidationErrors
+	)
+
+	switch {
+	case errors.As(err, &failures):
+		failures = append(types.ValidationErrors(nil), failures...)
+	case errors.As(err, &failure):
+		failures = types.ValidationErrors{*failure}
+	default:
+		failures = types.ValidationErrors{{Message: err.Error()}}
+	}
+
+	for i := range failures {
+		if failures[i].Location == "" {
+			failures[i].Location = location
+		}
+
+		if failures[i].Field == "" {
+			failures[i].Field = field
+		}
+
+		if failures[i].Rule == "" {
+			failures[i].Rule = rule
+		}
+	}
+
+	return failures
+}
+
+// Check - checks that all options can validate values of type.
+func Check(typ reflect.Type, options []Option) error {
+	for _, option := range options {
+		if checker, ok := option.(Checker); ok {
+			if err := checker.Check(typ); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// codings - content codings whose names are appended to entity tags of encoded responses, see 'EncodedETag'.
+var codings = []string{"gzip", "deflate", "br", "zstd"}
+
+// ETagMode - how entity tag is computed from marshaled payload if it wasn't set by handler.
+type ETagMode int
+
+const (
+	NoETag ETagMode = iota
+	StrongETag
+	WeakETag
+)
+
+// SetConditions - sets method and headers of request conditional headers are taken from.
+func SetConditions(resp *Response, method string, header http.Header) {
+	resp.method = method
+	resp.conditions = header
+}
+
+// SetETagMode - sets how entity tag is computed from marshaled payload.
+func SetETagMode(resp *Response, mode ETagMode) {
+	resp.etagMode = mode
+}
+
+func (resp *Response) WithETag(etag string) Responser {
+	resp.etag = quoteETag(etag)
+
+	return resp
+}
+
+func (resp *Response) WithLastModified(modified time.Time) Responser {
+	// HTTP dates have no fractions of seconds.
+	resp.lastModified = modified.UTC().Truncate(time.Second)
+
+	return resp
+}
+
+// Precondition - evaluates 'If-Match', 'If-Unmodified-Since' and 'If-None-Match' against current state of resource
+// before it's changed, responds with 412 if they failed.
+// Resource doesn't exist if both entity tag and modification time are empty.
+func Precondition(resp *Response, etag string, lastModified time.Time) error {
+	var code = evaluate(resp.method, resp.conditions,
+		quoteETag(etag), lastModified.UTC().Truncate(time.Second), false,
+	)
+
+	if code == http.StatusPreconditionFailed {
+		return resp.Error(http.StatusPreconditionFailed, ErrPreconditionFailed)
+	}
+
+	return nil
+}
+
+// conditional - sets validators of successful response and answers conditional 'GET' or 'HEAD' request.
+// Returns true if response was written instead of payload.
+func (resp *Response) conditional(code int, payload []byte) (bool, error) {
+	if code < http.StatusOK || code >= http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	var (
+		header = resp.writer.Header()
+		etag   = resp.etag
+	)
+
+	if etag == "" && payload != nil && resp.etagMode != NoETag {
+		etag = computeETag(payload, resp.etagMode == WeakETag)
+	}
+
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+
+	if !resp.lastModified.IsZero() {
+		header.Set("Last-Modified", resp.lastModified.Format(http.TimeFormat))
+	}
+
+	// Changing requests are evaluated against state before change, see 'Precondition'.
+	if resp.method != http.MethodGet && resp.method != http.MethodHead {
+		return false, nil
+	}
+
+	switch evaluate(resp.method, resp.conditions, etag, resp.lastModified, true) {
+	case http.StatusNotModified:
+		header.Del("Content-Type")
+		header.Del("Content-Length")
+		resp.writer.WriteHeader(http.StatusNotModified)
+
+		return true, nil
+	case http.StatusPreconditionFailed:
+		header.Del("ETag")
+		header.Del("Last-Modified")
+
+		return true, resp.Error(http.StatusPreconditionFailed, ErrPreconditionFailed)
+	default:
+		return false, nil
+	}
+}
+
+// evaluate - returns status code of failed precondition (see RFC 9110 section 13.2.2), zero if none failed.
+// 'exists' reports whether resource exists if it has no validators.
+func evaluate(method string, header http.Header, etag string, lastModified time.Time, exists bool) int {
+	if header == nil {
+		return 0
+	}
+
+	exists = exists || etag != "" || !lastModified.IsZero()
+
+	if values := header.Values("If-Match"); len(values) > 0 {
+		if !matchETag(values, etag, exists, true) {
+			return http.StatusPreconditionFailed
+		}
+	} else if since, ok := parseTime(header.Get("If-Unmodified-Since")); ok && !lastModified.IsZero() {
+		if lastModified.After(since) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	var safe = method == http.MethodGet || method == http.MethodHead
+
+	if values := header.Values("If-None-Match"); len(values) > 0 {
+		if matchETag(values, etag, exists, false) {
+			if safe {
+				return http.StatusNotModified
+			}
+
+			return http.StatusPreconditionFailed
+		}
+	} else if since, ok := parseTime(header.Get("If-Modified-Since")); ok && safe && !lastModified.IsZero() {
+		if !lastModified.After(since) {
+			return http.StatusNotModified
+		}
+	}
+
+	return 0
+}
+
+// matchETag - reports whether any of listed entity tags matches 'etag', '*' matches any existing resource.
+// Strong comparison requires both tags to be strong.
+func matchETag(values []string, etag string, exists, strong bool) bool {
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimSpace(tag)
+
+			switch {
+			case tag == "*":
+				if exists {
+					return true
+				}
+			case etag == "":
+			case strong:
+				if !isWeak(tag) && !isWeak(etag) && (tag == etag || decodedETag(tag) == etag) {
+					return true
+				}
+			default:
+				tag, etag := strings.TrimPrefix(tag, "W/"), strings.TrimPrefix(etag, "W/")
+
+				if tag == etag || decodedETag(tag) == etag {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// EncodedETag - returns strong entity tag of representation encoded with 'encoding', e.g. '"v7-gzip"',
+// so it differs from original one, but still matches it in conditional requests.
+func EncodedETag(etag, encoding string) string {
+	if isWeak(etag) || len(etag) < 2 || !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
+
+// decodedETag - returns entity tag without encoding added by 'EncodedETag'.
+func decodedETag(etag string) string {
+	for _, coding := range codings {
+		if trimmed, ok := strings.CutSuffix(etag, "-"+coding+`"`); ok {
+			return trimmed + `"`
+		}
+	}
+
+	return etag
+}
+
+func isWeak(etag string) bool {
+	return strings.HasPrefix(etag, "W/")
+}
+
+// quoteETag - adds quotes to entity tag if they're missing, 'W/' prefix is kept.
+func quoteETag(etag string) string {
+	if etag == "" {
+		return ""
+	}
+
+	var prefix string
+	if isWeak(etag) {
+		prefix, etag = "W/", strings.TrimPrefix(etag, "W/")
+	}
+
+	if len(etag) < 2 || !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) {
+		etag = `"` + strings.Trim(etag, `"`) + `"`
+	}
+
+	return prefix + etag
+}
+
+func computeETag(payload []byte, weak bool) string {
+	var (
+		sum  = sha256.Sum256(payload)
+		etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	)
+
+	if weak {
+		return "W/" + etag
+	}
+
+	return etag
+}
+
+func parseTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	parsed, err := http.ParseTime(value)
+
+	return parsed, err == nil
+}
@@ -1,8 +1,15 @@
 package response
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kliuchnikovv/engi/definition/codec"
 
 	"github.com/kliuchnikovv/engi/internal/types"
 )
@@ -35,15 +42,57 @@ type Responser interface {
 	NotFound(format string, args ...interface{}) error
 	// MethodNotAllowed - responses with 405 error code and provided formatted string message.
 	MethodNotAllowed(format string, args ...interface{}) error
+	// NotAcceptable - responses with 406 error code and provided formatted string message.
+	NotAcceptable(format string, args ...interface{}) error
+	// RequestEntityTooLarge - responses with 413 error code and provided formatted string message.
+	RequestEntityTooLarge(format string, args ...interface{}) error
+	// UnsupportedMediaType - responses with 415 error code and provided formatted string message.
+	UnsupportedMediaType(format string, args ...interface{}) error
+	// TooManyRequests - responses with 429 error code and provided formatted string message.
+	TooManyRequests(format string, args ...interface{}) error
 	// InternalServerError - responses with 500 error code and provided formatted string message.
 	InternalServerError(format string, args ...interface{}) error
+	// Stream - responses with 200 code and body written by 'write' without holding it in memory.
+	// Written data is flushed to client periodically, writing fails after request's context is cancelled.
+	Stream(contentType string, write func(w io.Writer) error) error
+	// Reader - responses with 200 code and body copied from 'reader', reader is closed if it's 'io.Closer'.
+	// Content type is detected by first bytes if it's not set in headers.
+	Reader(reader io.Reader) error
+	// WithETag - sets entity tag of response's resource, e.g. its version ('W/' prefix makes it weak).
+	// Quotes are added if missing. 'GET' and 'HEAD' requests with matching 'If-None-Match' are answered with 304.
+	WithETag(etag string) Responser
+	// WithLastModified - sets modification time of response's resource.
+	// 'GET' and 'HEAD' requests with 'If-Modified-Since' not before it are answered with 304.
+	WithLastModified(modified time.Time) Responser
 }
 
 // Response - provide methods for creating responses.
 type Response struct {
-	writer    http.ResponseWriter
+	writer    *statusWriter
 	marshaler types.Marshaler
 	object    types.Responser
+
+	// accept - value of request's 'Accept' header.
+	accept string
+	// offers - codecs response can be encoded with, only marshaler is used if empty.
+	offers []codec.Codec
+	// restricted - only offers can be used, marshaler isn't offered unless it's one of them.
+	restricted bool
+	// ctx - request's context, streaming stops when it's cancelled.
+	ctx context.Context
+	// heartbeat - interval of event stream's heartbeat comments, 'DefaultHeartbeat' if nil.
+	heartbeat *time.Duration
+	// closers - writers wrapping original one, closed after response is written.
+	closers []io.Closer
+
+	// method, conditions - method and headers of request, conditional headers are evaluated against them.
+	method     string
+	conditions http.Header
+	// etag, lastModified - validators of response's resource set by handler.
+	etag         string
+	lastModified time.Time
+	// etagMode - how entity tag is computed from payload if handler didn't set it.
+	etagMode ETagMode
 }
 
 func New(
@@ -52,21 +101,66 @@ func New(
 	object types.Responser,
 ) *Response {
 	return &Response{
-		writer:    writer,
+		writer:    &statusWriter{ResponseWriter: writer},
 		marshaler: marshaler,
 		object:    object,
 	}
 }
 
+// Written - reports whether status code was already sent to client.
+func (resp *Response) Written() bool {
+	return resp.writer.status != 0
+}
+
+// statusWriter - remembers status code sent through underlying writer,
+// so middlewares chain could be stopped after response was written.
+type statusWriter struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(bytes []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(bytes)
+}
+
+// Unwrap - returns original writer, used by http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func (resp *Response) Object(code int, payload interface{}) error {
+	marshaler, ok := resp.negotiate()
+	if !ok {
+		return resp.NotAcceptable("%s, supported: %s",
+			http.StatusText(http.StatusNotAcceptable), strings.Join(resp.offered(), ", "),
+		)
+	}
+
 	resp.object.SetPayload(payload)
 
-	bytes, err := resp.marshaler.Marshal(resp.object)
+	bytes, err := marshaler.Marshal(resp.object)
 	if err != nil {
 		return err
 	}
 
-	var contentType = resp.marshaler.ContentType()
+	if written, err := resp.conditional(code, bytes); written {
+		return err
+	}
+
+	var contentType = marshaler.ContentType()
 	if contentType != "" {
 		resp.writer.Header().Add("Content-Type", contentType)
 	}
@@ -81,14 +175,17 @@ func (resp *Response) Object(code int, payload interface{}) error {
 }
 
 func (resp *Response) Error(code int, err error) error {
+	// Errors are responded even if client accepts none of offered types.
+	marshaler, _ := resp.negotiate()
+
 	resp.object.SetError(err)
 
-	bytes, err := resp.marshaler.Marshal(resp.object)
+	bytes, err := marshaler.Marshal(resp.object)
 	if err != nil {
 		return err
 	}
 
-	var contentType = resp.marshaler.ContentType()
+	var contentType = marshaler.ContentType()
 	if contentType != "" {
 		resp.writer.Header().Add("Content-Type", contentType)
 	}
@@ -106,6 +203,10 @@ func (resp *Response) Errorf(code int, format string, args ...any) error {
 }
 
 func (resp *Response) WithoutContent(code int) error {
+	if written, err := resp.conditional(code, nil); written {
+		return err
+	}
+
 	resp.writer.WriteHeader(code)
 	return nil // in purpose of unification
 }
@@ -138,6 +239,22 @@ func (resp *Response) MethodNotAllowed(format string, args ...interface{}) error
 	return resp.Errorf(http.StatusMethodNotAllowed, format, args...)
 }
 
+func (resp *Response) RequestEntityTooLarge(format string, args ...interface{}) error {
+	return resp.Errorf(http.StatusRequestEntityTooLarge, format, args...)
+}
+
+func (resp *Response) NotAcceptable(format string, args ...interface{}) error {
+	return resp.Errorf(http.StatusNotAcceptable, format, args...)
+}
+
+func (resp *Response) UnsupportedMediaType(format string, args ...interface{}) error {
+	return resp.Errorf(http.StatusUnsupportedMediaType, format, args...)
+}
+
+func (resp *Response) TooManyRequests(format string, args ...interface{}) error {
+	return resp.Errorf(http.StatusTooManyRequests, format, args...)
+}
+
 func (resp *Response) InternalServerError(format string, args ...interface{}) error {
 	return resp.Errorf(http.StatusInternalServerError, format, args...)
 }
@@ -146,3 +263,61 @@ func (resp *Response) ResponseWriter() http.ResponseWriter {
 	return resp.writer
 }
 
+// negotiate - returns marshaler of offered type client accepts, marshaler is preferred on equal quality.
+// Returns marshaler and false if client accepts none of offered types.
+func (resp *Response) negotiate() (types.Marshaler, bool) {
+	if len(resp.offers) == 0 {
+		return resp.marshaler, true
+	}
+
+	var offered = resp.offered()
+
+	// Negotiation is called for every written object, header should be added once.
+	if header := resp.writer.Header(); len(offered) > 1 && !slices.Contains(header.Values("Vary"), "Accept") {
+		header.Add("Vary", "Accept")
+	}
+
+	contentType, ok := codec.Negotiate(resp.accept, offered)
+	if !ok {
+		return resp.marshaler, false
+	}
+
+	if strings.EqualFold(contentType, resp.marshaler.ContentType()) {
+		return resp.marshaler, true
+	}
+
+	for _, offer := range resp.offers {
+		if strings.EqualFold(contentType, offer.ContentType()) {
+			return types.Marshaler{
+				ContentType: offer.ContentType,
+				Marshal:     offer.Marshal,
+			}, true
+		}
+	}
+
+	return resp.marshaler, true
+}
+
+// offered - returns offered media types, type of marshaler goes first.
+// Type of marshaler is always offered unless offers are restricted.
+func (resp *Response) offered() []string {
+	var (
+		preferred = resp.marshaler.ContentType()
+		result    = make([]string, 0, len(resp.offers)+1)
+	)
+
+	if !resp.restricted {
+		result = append(result, preferred)
+	}
+
+	for _, offer := range resp.offers {
+		switch {
+		case !strings.EqualFold(offer.ContentType(), preferred):
+			result = append(result, offer.ContentType())
+		case resp.restricted:
+			result = append([]string{offer.ContentType()}, result...)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,230 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHeartbeat - interval of heartbeat comments keeping idle event stream alive through proxies.
+const DefaultHeartbeat = 15 * time.Second
+
+type (
+	// Event - server-sent event.
+	Event struct {
+		// ID - identifier client sends in 'Last-Event-ID' header after reconnection.
+		ID string
+		// Event - type of event, client gets 'message' events if empty.
+		Event string
+		// Data - payload of event: strings and bytes are sent as is, other values are marshaled to JSON.
+		Data any
+		// Retry - time client should wait before reconnection.
+		Retry time.Duration
+	}
+
+	// SSEWriter - sends server-sent events to client.
+	SSEWriter interface {
+		// Send - sends event and flushes it to client.
+		Send(event Event) error
+		// Data - sends 'message' event with data.
+		Data(data any) error
+		// Comment - sends comment ignored by client.
+		Comment(text string) error
+		// LastEventID - returns identifier of last event client got before reconnection, empty for new client.
+		LastEventID() string
+	}
+)
+
+// SetHeartbeat - sets interval of heartbeat comments of event stream, zero disables them.
+func SetHeartbeat(resp *Response, interval time.Duration) {
+	resp.heartbeat = &interval
+}
+
+// EventStream - responds with 'text/event-stream' and passes writer of events to 'handle'.
+// Server's write timeout isn't applied to stream, stream is closed when 'handle' returns or client goes away.
+func (resp *Response) EventStream(lastEventID string, handle func(SSEWriter) error) error {
+	var (
+		controller = http.NewResponseController(resp.writer)
+		header     = resp.writer.Header()
+	)
+
+	// Stream lives until client goes away, so connection's deadline must not cut it.
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+
+	resp.writer.WriteHeader(http.StatusOK)
+
+	if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	var stream = &eventStream{
+		ctx:         resp.context(),
+		writer:      resp.writer,
+		controller:  controller,
+		lastEventID: lastEventID,
+	}
+
+	var interval = DefaultHeartbeat
+	if resp.heartbeat != nil {
+		interval = *resp.heartbeat
+	}
+
+	if interval > 0 {
+		var (
+			done  = make(chan struct{})
+			group sync.WaitGroup
+		)
+
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			stream.heartbeat(interval, done)
+		}()
+
+		// Nothing can be written after response is finished.
+		defer group.Wait()
+		defer close(done)
+	}
+
+	return handle(stream)
+}
+
+type eventStream struct {
+	mutex       sync.Mutex
+	ctx         context.Context
+	writer      io.Writer
+	controller  *http.ResponseController
+	lastEventID string
+}
+
+func (stream *eventStream) Send(event Event) error {
+	var builder strings.Builder
+
+	if event.ID != "" {
+		if strings.ContainsAny(event.ID, "\r\n\x00") {
+			return fmt.Errorf("invalid event id: %q", event.ID)
+		}
+
+		fmt.Fprintf(&builder, "id: %s\n", event.ID)
+	}
+
+	if event.Event != "" {
+		if strings.ContainsAny(event.Event, "\r\n") {
+			return fmt.Errorf("invalid event type: %q", event.Event)
+		}
+
+		fmt.Fprintf(&builder, "event: %s\n", event.Event)
+	}
+
+	if event.Retry > 0 {
+		fmt.Fprintf(&builder, "retry: %d\n", event.Retry.Milliseconds())
+	}
+
+	data, err := eventData(event.Data)
+	if err != nil {
+		return err
+	}
+
+	// Every line of data is sent in its own field.
+	if event.Data != nil {
+		for _, line := range strings.Split(strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(data), "\n") {
+			fmt.Fprintf(&builder, "data: %s\n", line)
+		}
+	}
+
+	builder.WriteString("\n")
+
+	return stream.write(builder.String())
+}
+
+func (stream *eventStream) Data(data any) error {
+	return stream.Send(Event{Data: data})
+}
+
+func (stream *eventStream) Comment(text string) error {
+	var builder strings.Builder
+
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(&builder, ": %s\n", strings.TrimSuffix(line, "\r"))
+	}
+
+	builder.WriteString("\n")
+
+	return stream.write(builder.String())
+}
+
+func (stream *eventStream) LastEventID() string {
+	return stream.lastEventID
+}
+
+// write - sends message and flushes it, fails after request's context is cancelled.
+func (stream *eventStream) write(message string) error {
+	if err := stream.ctx.Err(); err != nil {
+		return err
+	}
+
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	if _, err := io.WriteString(stream.writer, message); err != nil {
+		return err
+	}
+
+	if err := stream.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	return nil
+}
+
+// heartbeat - sends comments until 'done' is closed or client goes away.
+func (stream *eventStream) heartbeat(interval time.Duration, done <-chan struct{}) {
+	var ticker = time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-stream.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := stream.Comment("heartbeat"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// eventData - returns textual representation of event's data.
+func eventData(data any) (string, error) {
+	switch typed := data.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typed, nil
+	case []byte:
+		return string(typed), nil
+	default:
+		bytes, err := json.Marshal(typed)
+		if err != nil {
+			return "", err
+		}
+
+		return string(bytes), nil
+	}
+}
@@ -0,0 +1,167 @@
+package response
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// flushInterval - how long written data can stay buffered before it's sent to client.
+	flushInterval = 100 * time.Millisecond
+	// streamWriteTimeout - how long stream can stay blocked on writing to client,
+	// server's write timeout is replaced by it and extended while data is written.
+	streamWriteTimeout = 10 * time.Second
+)
+
+// SetContext - sets request's context, streaming stops when it's cancelled.
+func SetContext(resp *Response, ctx context.Context) {
+	resp.ctx = ctx
+}
+
+func (resp *Response) context() context.Context {
+	if resp.ctx == nil {
+		return context.Background()
+	}
+
+	return resp.ctx
+}
+
+func (resp *Response) Stream(contentType string, write func(w io.Writer) error) error {
+	if contentType != "" {
+		resp.writer.Header().Set("Content-Type", contentType)
+	}
+
+	resp.writer.WriteHeader(http.StatusOK)
+
+	var writer = newFlushWriter(resp.context(), resp.writer)
+
+	var err = write(writer)
+
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func (resp *Response) Reader(reader io.Reader) error {
+	if closer, ok := reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	var contentType = resp.writer.Header().Get("Content-Type")
+
+	// Content type is detected by first bytes like 'http.ServeContent' does.
+	if contentType == "" {
+		var head = make([]byte, 512)
+
+		n, err := io.ReadFull(reader, head)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		contentType = http.DetectContentType(head[:n])
+		reader = io.MultiReader(bytes.NewReader(head[:n]), reader)
+	}
+
+	return resp.Stream(contentType, func(w io.Writer) error {
+		_, err := io.Copy(w, reader)
+
+		return err
+	})
+}
+
+// flushWriter - writer sending buffered data to client at most 'flushInterval' after it was written.
+// Writing fails after request's context is cancelled.
+type flushWriter struct {
+	mutex      sync.Mutex
+	ctx        context.Context
+	writer     io.Writer
+	controller *http.ResponseController
+	timer      *time.Timer
+	closed     bool
+	// extended - when write deadline was extended last time.
+	extended time.Time
+}
+
+func newFlushWriter(ctx context.Context, writer http.ResponseWriter) *flushWriter {
+	return &flushWriter{
+		ctx:        ctx,
+		writer:     writer,
+		controller: http.NewResponseController(writer),
+	}
+}
+
+func (w *flushWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	w.extendDeadline()
+
+	n, err := w.writer.Write(p)
+
+	if w.timer == nil {
+		w.timer = time.AfterFunc(flushInterval, w.flush)
+	}
+
+	return n, err
+}
+
+func (w *flushWriter) flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.timer = nil
+
+	if !w.closed {
+		w.extendDeadline()
+		w.controller.Flush()
+	}
+}
+
+// extendDeadline - moves write deadline of connection, so long stream isn't cut by server's write timeout.
+func (w *flushWriter) extendDeadline() {
+	var now = time.Now()
+
+	if now.Sub(w.extended) < flushInterval {
+		return
+	}
+
+	if err := w.controller.SetWriteDeadline(now.Add(streamWriteTimeout)); err == nil {
+		w.extended = now
+	}
+}
+
+// Close - sends buffered data, writer can't be used after it.
+func (w *flushWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+
+	w.closed = true
+
+	w.extendDeadline()
+
+	if err := w.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,9 @@
 package response
 
-import "github.com/kliuchnikovv/engi/internal/types"
+import (
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/internal/types"
+)
 
 func SetMarshaler(resp *Response, marshaler types.Marshaler) {
 	resp.marshaler = marshaler
@@ -9,3 +12,19 @@ func SetMarshaler(resp *Response, marshaler types.Marshaler) {
 func SetResponser(resp *Response, responser types.Responser) {
 	resp.object = responser
 }
+
+// SetAccept - sets value of request's 'Accept' header used to choose marshaler.
+func SetAccept(resp *Response, accept string) {
+	resp.accept = accept
+}
+
+// SetOffers - sets codecs response can be encoded with.
+func SetOffers(resp *Response, offers []codec.Codec) {
+	resp.offers = offers
+}
+
+// RestrictOffers - sets the only codecs response can be encoded with, marshaler is used only if it's one of them.
+func RestrictOffers(resp *Response, offers []codec.Codec) {
+	resp.offers = offers
+	resp.restricted = true
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"io"
+	"net/http"
+)
+
+// WrapWriter - replaces writer of response with one returned by 'wrap' (e.g. compressing),
+// 'wrap' gets current writer. Writer is closed by 'Close' if it's 'io.Closer'.
+func WrapWriter(resp *Response, wrap func(http.ResponseWriter) http.ResponseWriter) {
+	var writer = wrap(resp.writer.ResponseWriter)
+
+	resp.writer.ResponseWriter = writer
+
+	if closer, ok := writer.(io.Closer); ok {
+		resp.closers = append(resp.closers, closer)
+	}
+}
+
+// Close - closes writers wrapping original one, should be called after response was written.
+func Close(resp *Response) error {
+	var result error
+
+	for i := len(resp.closers) - 1; i >= 0; i-- {
+		if err := resp.closers[i].Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+
+	resp.closers = nil
+
+	return result
+}
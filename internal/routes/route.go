@@ -2,9 +2,12 @@ package routes
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"sort"
 
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/response"
 	"github.com/kliuchnikovv/engi/internal/types"
@@ -15,9 +18,19 @@ type Route struct {
 	handler Handler
 
 	middlewares []Middleware
+	preflight   []Preflighter
 
 	Marshaler types.Marshaler
 	Responser types.Responser
+	// Codecs - codecs decoding request bodies and encoding responses.
+	Codecs *codec.Registry
+	// Uploads - files expected in multipart form by field name.
+	Uploads map[string]request.Upload
+	// Fields - names of non-file form fields read by route's parameters.
+	Fields map[string]struct{}
+
+	// Operation - route's documentation filled by middlewares.
+	Operation *docs.Operation
 
 	// auth   func(r *http.Request, w http.ResponseWriter) error
 	// Body   Option
@@ -35,6 +48,7 @@ func NewRoute(
 	handler Handler,
 	marshaler types.Marshaler,
 	responser types.Responser,
+	codecs *codec.Registry,
 	middlewares ...Middleware,
 	// options ...Middleware,
 ) (*Route, error) {
@@ -43,6 +57,8 @@ func NewRoute(
 		handler:     handler,
 		Marshaler:   marshaler,
 		Responser:   responser,
+		Codecs:      codecs,
+		Operation:   docs.NewOperation(),
 		middlewares: middlewares,
 		// auth: func(r *http.Request, w http.ResponseWriter) error {
 		// 	return nil
@@ -50,10 +66,24 @@ func NewRoute(
 		// Params: make(map[placing.Placing]map[string]Middleware),
 	}
 
-	sort.Slice(route.middlewares, func(i, j int) bool {
+	sort.SliceStable(route.middlewares, func(i, j int) bool {
 		return route.middlewares[i].Priority() < route.middlewares[j].Priority()
 	})
 
+	for _, middleware := range route.middlewares {
+		if binder, ok := middleware.(Binder); ok {
+			if err := binder.Bind(&route); err != nil {
+				return nil, err
+			}
+		}
+
+		if preflighter, ok := middleware.(Preflighter); ok {
+			route.preflight = append(route.preflight, preflighter)
+		}
+
+		middleware.Docs(&route)
+	}
+
 	// for _, option := range options {
 	// 	if err := option.Bind(&route); err != nil {
 	// 		return nil, err
@@ -68,15 +98,56 @@ func (route *Route) Handle(
 	request *request.Request,
 	writer http.ResponseWriter,
 ) error {
-	var response = response.New(writer,
-		route.Marshaler,
-		route.Responser,
-	)
+	var resp = route.newResponse(request, writer)
+
+	var err = route.handle(ctx, request, resp)
+
+	// Wrapping writers (e.g. compressing) send buffered data after handler finished.
+	if closeErr := response.Close(resp); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+func (route *Route) handle(
+	ctx context.Context,
+	request *request.Request,
+	response *response.Response,
+) error {
+	route.expectForm(request)
+
+	// Validation failures of all parameters are collected and responded together.
+	var failures types.ValidationErrors
 
 	for _, middleware := range route.middlewares {
+		if _, ok := middleware.(Dependent); ok && len(failures) > 0 {
+			continue
+		}
+
 		if err := middleware.Handle(ctx, request, response); err != nil {
+			if response.Written() {
+				return err
+			}
+
+			var errs types.ValidationErrors
+			if errors.As(err, &errs) {
+				failures = append(failures, errs...)
+
+				continue
+			}
+
 			return response.BadRequest(err.Error())
 		}
+
+		// Middleware already responded (e.g. rejected request) - handler shouldn't be called.
+		if response.Written() {
+			return nil
+		}
+	}
+
+	if len(failures) > 0 {
+		return response.Error(http.StatusBadRequest, failures)
 	}
 
 	return route.handler(
@@ -86,6 +157,63 @@ func (route *Route) Handle(
 	)
 }
 
+// expectForm - sets fields and files route's middlewares expect in form.
+func (route *Route) expectForm(r *request.Request) {
+	if route.Uploads != nil {
+		request.SetUploads(r, route.Uploads)
+	}
+
+	if route.Fields != nil {
+		request.SetFields(r, route.Fields)
+	}
+}
+
+// newResponse - creates response encoded by route's codec client accepts, route's marshaler is preferred.
+func (route *Route) newResponse(request *request.Request, writer http.ResponseWriter) *response.Response {
+	var result = response.New(writer,
+		route.Marshaler,
+		route.Responser,
+	)
+
+	response.SetContext(result, request.GetRequest().Context())
+	response.SetConditions(result, request.GetRequest().Method, request.GetRequest().Header)
+
+	if route.Codecs != nil {
+		response.SetAccept(result, request.GetRequest().Header.Get("Accept"))
+		response.SetOffers(result, route.Codecs.Codecs())
+	}
+
+	return result
+}
+
+// Preflight - answers CORS preflight request using only preflight middlewares.
+func (route *Route) Preflight(
+	ctx context.Context,
+	request *request.Request,
+	writer http.ResponseWriter,
+) error {
+	var response = response.New(writer,
+		route.Marshaler,
+		route.Responser,
+	)
+
+	for _, preflighter := range route.preflight {
+		if err := preflighter.Preflight(ctx, request, response); err != nil {
+			if response.Written() {
+				return err
+			}
+
+			return response.BadRequest(err.Error())
+		}
+
+		if response.Written() {
+			return nil
+		}
+	}
+
+	return response.NoContent()
+}
+
 // func (route *Route) newRequest(
 // 	r *http.Request,
 // 	path string,
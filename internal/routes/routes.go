@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"regexp"
 
+	"github.com/kliuchnikovv/engi/definition/codec"
 	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/response"
 	"github.com/kliuchnikovv/engi/internal/types"
@@ -46,29 +47,33 @@ func (routes Routes) Add(
 	handler Handler,
 	marshaler types.Marshaler,
 	responser types.Responser,
+	codecs *codec.Registry,
 	options ...Middleware,
-) error {
-	route, err := NewRoute(path, handler, marshaler, responser, options...)
+) (*Route, error) {
+	route, err := NewRoute(path, handler, marshaler, responser, codecs, options...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	routes.root.Add(method, path, route)
 
-	return nil
+	return route, nil
 }
 
 func (routes Routes) Handle(
 	ctx context.Context,
-	req *http.Request,
+	request *request.Request,
 	writer http.ResponseWriter,
 	method string,
 	path string,
 ) error {
-	var request = request.New(req)
-
 	route, err := routes.root.Get(request, method, path)
 	if err != nil {
+		if preflight, ok := routes.preflight(request, path); ok {
+			// Error is logged by service.
+			return preflight.Preflight(ctx, request, writer)
+		}
+
 		writer.WriteHeader(http.StatusNotFound)
 		writer.Write([]byte(err.Error()))
 
@@ -84,6 +89,25 @@ func (routes Routes) Handle(
 	return nil
 }
 
+// preflight - finds route for CORS preflight request if it wasn't registered explicitly.
+func (routes Routes) preflight(request *request.Request, path string) (*Route, bool) {
+	var (
+		req    = request.GetRequest()
+		method = req.Header.Get("Access-Control-Request-Method")
+	)
+
+	if req.Method != http.MethodOptions || method == "" {
+		return nil, false
+	}
+
+	route, err := routes.root.Get(request, method, path)
+	if err != nil || len((*route).preflight) == 0 {
+		return nil, false
+	}
+
+	return *route, true
+}
+
 // func (routes Routes) matchEndpoint(method, path string) (*Route, error) {
 // 	var (
 // 		exactRoutes, exactHandlerFound   = routes.exactHandlers[method]
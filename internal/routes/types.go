@@ -19,6 +19,25 @@ type (
 		Docs(*Route)
 		Priority() int
 	}
+
+	// Binder - middleware checking its configuration when route is registered,
+	// error fails registration of route.
+	Binder interface {
+		Bind(*Route) error
+	}
+
+	// Preflighter - middleware taking part in answering CORS preflight requests.
+	// Such requests are answered automatically for every registered path,
+	// only middlewares implementing this interface are called.
+	Preflighter interface {
+		Preflight(context.Context, *request.Request, *response.Response) error
+	}
+
+	// Dependent - middleware relying on values of request's parameters,
+	// it's skipped if any of them failed validation.
+	Dependent interface {
+		DependsOnParameters()
+	}
 )
 
 func contains(slice []string, item string) bool {
@@ -0,0 +1,33 @@
+package types
+
+import "strings"
+
+// TagRule - single rule of 'validate' struct tag, e.g. 'min=1'.
+type TagRule struct {
+	Name  string
+	Param string
+}
+
+// ParseTag - parses 'validate' struct tag: 'required,min=1,oneof=a b'.
+// Rules after 'dive' are applied to elements of slice or map.
+func ParseTag(tag string) (rules, dive []TagRule) {
+	var target = &rules
+
+	for _, part := range strings.Split(tag, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+
+		name, param, _ := strings.Cut(part, "=")
+
+		if name == "dive" {
+			target = &dive
+
+			continue
+		}
+
+		*target = append(*target, TagRule{Name: name, Param: param})
+	}
+
+	return rules, dive
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 )
 
@@ -60,35 +61,77 @@ func (obj *ResponseAsIs) SetPayload(object interface{}) {
 }
 
 // SetError - sets error response into object.
+// Validation errors are set as list of failures.
 func (obj *ResponseAsIs) SetError(err error) {
+	var failures ValidationErrors
+	if errors.As(err, &failures) {
+		obj.Response = failures
+
+		return
+	}
+
 	obj.Response = err.Error()
 }
 
+// Payload - returns payload, so formats other than JSON and XML encode it without wrapping.
+func (obj *ResponseAsIs) Payload() interface{} {
+	return obj.Response
+}
+
+// String - returns textual representation of payload, used to respond plain text.
+func (obj *ResponseAsIs) String() string {
+	if obj.Response == nil {
+		return ""
+	}
+
+	return fmt.Sprint(obj.Response)
+}
+
 func (obj *ResponseAsIs) MarshalJSON() ([]byte, error) {
 	return json.Marshal(obj.Response)
 }
 
 type ResponseAsObject struct {
-	XMLName     xml.Name    `json:"-"                xml:"response"`
-	Code        int         `json:"-"                xml:"-"`
-	Result      interface{} `json:"result,omitempty" xml:"result,omitempty"`
-	ErrorString string      `json:"error,omitempty"  xml:"error,omitempty"`
+	XMLName     xml.Name    `json:"-"                xml:"response"                yaml:"-"`
+	Code        int         `json:"-"                xml:"-"                       yaml:"-"`
+	Result      interface{} `json:"result,omitempty" xml:"result,omitempty"        yaml:"result,omitempty"`
+	ErrorString string      `json:"error,omitempty"  xml:"error,omitempty"         yaml:"error,omitempty"`
+	// Errors - pointer, so empty list isn't encoded in XML.
+	Errors *ValidationErrors `json:"errors,omitempty" xml:"errors>error,omitempty" yaml:"errors,omitempty"`
 }
 
-// SetPayload - sets response payload into object.
+// SetPayload - sets response payload into object, previous error is reset.
 func (a *ResponseAsObject) SetPayload(object interface{}) {
 	a.Result = object
+	a.ErrorString = ""
+	a.Errors = nil
 }
 
-// SetError - sets error response into object.
+// SetError - sets error response into object, previous payload is reset.
 func (a *ResponseAsObject) SetError(err error) {
+	a.Result = nil
 	a.ErrorString = err.Error()
+	a.Errors = nil
+
+	var failures ValidationErrors
+	if errors.As(err, &failures) {
+		a.Errors = &failures
+	}
 }
 
 func (a ResponseAsObject) Error() string {
 	return a.ErrorString
 }
 
+// String - returns error or textual representation of result, used to respond plain text.
+func (a *ResponseAsObject) String() string {
+	if a.ErrorString != "" || a.Result == nil {
+		return a.ErrorString
+	}
+
+	return fmt.Sprint(a.Result)
+}
+
 func AsError(code int, format string, args ...interface{}) *ResponseAsObject {
 	return &ResponseAsObject{
 		Code:        code,
@@ -0,0 +1,34 @@
+package types
+
+import "strings"
+
+type (
+	// ValidationError - failure of single validation rule.
+	ValidationError struct {
+		// Location - where value was sent: 'path', 'query', 'header', 'cookie', 'body' or 'request'.
+		Location string `json:"location"        xml:"location,attr"           yaml:"location"`
+		// Field - name of parameter or path to body's field (e.g. 'items[0].name').
+		Field string `json:"field,omitempty" xml:"field,attr,omitempty"   yaml:"field,omitempty"`
+		// Rule - name of failed rule (e.g. 'required', 'type', 'lt').
+		Rule string `json:"rule"            xml:"rule,attr"               yaml:"rule"`
+		// Message - human readable description of failure.
+		Message string `json:"message"         xml:",chardata"               yaml:"message"`
+	}
+
+	// ValidationErrors - all validation failures of request.
+	ValidationErrors []ValidationError
+)
+
+func (err *ValidationError) Error() string {
+	return err.Message
+}
+
+func (errs ValidationErrors) Error() string {
+	var messages = make([]string, len(errs))
+
+	for i := range errs {
+		messages[i] = errs[i].Message
+	}
+
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,585 @@
+package websocket
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// MessageType - type of data message.
+type MessageType int
+
+const (
+	TextMessage   MessageType = 1
+	BinaryMessage MessageType = 2
+)
+
+// StatusCode - code of close frame, see RFC 6455 section 7.4.
+type StatusCode int
+
+const (
+	StatusNormalClosure   StatusCode = 1000
+	StatusGoingAway       StatusCode = 1001
+	StatusProtocolError   StatusCode = 1002
+	StatusUnsupportedData StatusCode = 1003
+	StatusNoStatus        StatusCode = 1005
+	StatusInvalidPayload  StatusCode = 1007
+	StatusPolicyViolation StatusCode = 1008
+	StatusMessageTooBig   StatusCode = 1009
+	StatusInternalError   StatusCode = 1011
+)
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+
+	// maxControlPayload - limit of control frame's payload.
+	maxControlPayload = 125
+	// writeTimeout - how long single frame can be written.
+	writeTimeout = 10 * time.Second
+	// closeTimeout - how long peer's close frame is awaited after sending own one.
+	closeTimeout = 5 * time.Second
+)
+
+// ErrClosed - connection is closed, nothing can be written to it.
+var ErrClosed = errors.New("websocket connection closed")
+
+// CloseError - close frame got from peer.
+type CloseError struct {
+	Code   StatusCode
+	Reason string
+}
+
+func (err *CloseError) Error() string {
+	if err.Reason == "" {
+		return fmt.Sprintf("websocket closed: %d", err.Code)
+	}
+
+	return fmt.Sprintf("websocket closed: %d %s", err.Code, err.Reason)
+}
+
+// protocolError - violation of protocol by peer, connection is closed with code.
+type protocolError struct {
+	code   StatusCode
+	reason string
+}
+
+func (err *protocolError) Error() string {
+	return "websocket: " + err.reason
+}
+
+type message struct {
+	typ  MessageType
+	data []byte
+}
+
+// Conn - WebSocket connection.
+// Frames are read in background: pings are answered and data messages are queued for 'ReadMessage'.
+// Methods can be called concurrently.
+type Conn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	options Options
+	// client - frames are sent masked and got unmasked.
+	client bool
+	// compress - permessage-deflate extension was negotiated.
+	compress bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	writeMutex sync.Mutex
+	closeSent  bool
+	// closing - closed when close frame was sent, unread messages are dropped after it.
+	closing chan struct{}
+
+	messages chan message
+	readErr  error
+	readDone chan struct{}
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func newConn(
+	ctx context.Context,
+	conn net.Conn,
+	reader *bufio.Reader,
+	client, compress bool,
+	options Options,
+) *Conn {
+	var c = &Conn{
+		conn:     conn,
+		reader:   reader,
+		options:  options,
+		client:   client,
+		compress: compress,
+		closing:  make(chan struct{}),
+		messages: make(chan message),
+		readDone: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	c.ctx, c.cancel = context.WithCancel(ctx)
+
+	go c.readLoop()
+
+	if options.PingInterval > 0 {
+		go c.pingLoop()
+	}
+
+	return c
+}
+
+// Context - returns context cancelled when connection is closed.
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// Compressed - reports whether permessage-deflate extension was negotiated.
+func (c *Conn) Compressed() bool {
+	return c.compress
+}
+
+// ReadMessage - returns next data message.
+// Returns '*CloseError' after peer closed connection.
+func (c *Conn) ReadMessage() (MessageType, []byte, error) {
+	msg, ok := <-c.messages
+	if !ok {
+		return 0, nil, c.readErr
+	}
+
+	return msg.typ, msg.data, nil
+}
+
+// ReadJSON - reads next data message and unmarshals it from JSON into 'v'.
+func (c *Conn) ReadJSON(v any) error {
+	_, data, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// WriteMessage - sends data message.
+func (c *Conn) WriteMessage(typ MessageType, data []byte) error {
+	if typ != TextMessage && typ != BinaryMessage {
+		return fmt.Errorf("unknown message type: %d", typ)
+	}
+
+	if !c.compress {
+		return c.writeFrame(byte(typ), false, data)
+	}
+
+	compressed, err := compress(data)
+	if err != nil {
+		return err
+	}
+
+	return c.writeFrame(byte(typ), true, compressed)
+}
+
+// WriteJSON - marshals 'v' to JSON and sends it in text message.
+func (c *Conn) WriteJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return c.WriteMessage(TextMessage, data)
+}
+
+// Close - sends close frame with code and reason, waits for peer's one and closes connection.
+// Calling it on already closed connection does nothing.
+func (c *Conn) Close(code StatusCode, reason string) error {
+	var err = c.writeFrame(opClose, false, closePayload(code, reason))
+	if err == nil {
+		select {
+		case <-c.readDone:
+		case <-time.After(closeTimeout):
+		}
+	}
+
+	c.closeConn()
+
+	if errors.Is(err, ErrClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (c *Conn) closeConn() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.conn.Close()
+		c.cancel()
+	})
+}
+
+// readLoop - reads frames until connection is closed, data messages are passed to 'ReadMessage'.
+func (c *Conn) readLoop() {
+	defer close(c.readDone)
+
+	for {
+		typ, data, err := c.readMessage()
+		if err != nil {
+			c.readErr = err
+			close(c.messages)
+			c.finish(err)
+
+			return
+		}
+
+		select {
+		case c.messages <- message{typ: typ, data: data}:
+		case <-c.closing:
+			// Messages are read until peer's close frame.
+		case <-c.done:
+			c.readErr = ErrClosed
+			close(c.messages)
+
+			return
+		}
+	}
+}
+
+// finish - answers peer's close frame or protocol violation and closes connection.
+func (c *Conn) finish(err error) {
+	var (
+		closeErr    *CloseError
+		protocolErr *protocolError
+	)
+
+	switch {
+	case errors.As(err, &closeErr):
+		var code = closeErr.Code
+		if code == StatusNoStatus {
+			code = StatusNormalClosure
+		}
+
+		_ = c.writeFrame(opClose, false, closePayload(code, ""))
+	case errors.As(err, &protocolErr):
+		_ = c.writeFrame(opClose, false, closePayload(protocolErr.code, protocolErr.reason))
+	}
+
+	c.closeConn()
+}
+
+// pingLoop - pings peer until connection is closed, see 'readMessage' for awaiting of answers.
+func (c *Conn) pingLoop() {
+	var ticker = time.NewTicker(c.options.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := c.writeFrame(opPing, false, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readMessage - reads frames of next data message, control frames are handled in between.
+func (c *Conn) readMessage() (MessageType, []byte, error) {
+	var (
+		typ        MessageType
+		compressed bool
+		payload    []byte
+	)
+
+	for {
+		// Peer answers pings, so connection is dead if nothing came for two intervals.
+		if c.options.PingInterval > 0 {
+			if err := c.conn.SetReadDeadline(time.Now().Add(2 * c.options.PingInterval)); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		header, err := c.readHeader()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if header.opcode < opClose && int64(len(payload))+header.length > c.options.ReadLimit {
+			return 0, nil, &protocolError{code: StatusMessageTooBig, reason: "message too big"}
+		}
+
+		data, err := c.readPayload(header)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch header.opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, false, data); err != nil && !errors.Is(err, ErrClosed) {
+				return 0, nil, err
+			}
+
+			continue
+		case opPong:
+			continue
+		case opClose:
+			return 0, nil, parseClose(data)
+		case opText, opBinary:
+			if typ != 0 {
+				return 0, nil, &protocolError{code: StatusProtocolError, reason: "continuation frame expected"}
+			}
+
+			typ, compressed = MessageType(header.opcode), header.rsv1
+		case opContinuation:
+			if typ == 0 {
+				return 0, nil, &protocolError{code: StatusProtocolError, reason: "unexpected continuation frame"}
+			}
+		default:
+			return 0, nil, &protocolError{code: StatusProtocolError, reason: fmt.Sprintf("unknown opcode: %d", header.opcode)}
+		}
+
+		payload = append(payload, data...)
+
+		if header.fin {
+			break
+		}
+	}
+
+	if compressed {
+		var err error
+
+		if payload, err = decompress(payload, c.options.ReadLimit); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	if typ == TextMessage && !utf8.Valid(payload) {
+		return 0, nil, &protocolError{code: StatusInvalidPayload, reason: "invalid UTF-8 in text message"}
+	}
+
+	return typ, payload, nil
+}
+
+type frameHeader struct {
+	fin    bool
+	rsv1   bool
+	opcode byte
+	length int64
+	masked bool
+	mask   [4]byte
+}
+
+func (c *Conn) readHeader() (frameHeader, error) {
+	var (
+		header frameHeader
+		buf    [8]byte
+	)
+
+	if _, err := io.ReadFull(c.reader, buf[:2]); err != nil {
+		return header, err
+	}
+
+	header.fin = buf[0]&0x80 != 0
+	header.rsv1 = buf[0]&0x40 != 0
+	header.opcode = buf[0] & 0x0f
+	header.masked = buf[1]&0x80 != 0
+	header.length = int64(buf[1] & 0x7f)
+
+	if buf[0]&0x30 != 0 {
+		return header, &protocolError{code: StatusProtocolError, reason: "reserved bits are set"}
+	}
+
+	switch header.length {
+	case 126:
+		if _, err := io.ReadFull(c.reader, buf[:2]); err != nil {
+			return header, err
+		}
+
+		header.length = int64(binary.BigEndian.Uint16(buf[:2]))
+	case 127:
+		if _, err := io.ReadFull(c.reader, buf[:8]); err != nil {
+			return header, err
+		}
+
+		var length = binary.BigEndian.Uint64(buf[:8])
+		if length>>63 != 0 {
+			return header, &protocolError{code: StatusProtocolError, reason: "invalid payload length"}
+		}
+
+		header.length = int64(length)
+	}
+
+	if header.masked {
+		if _, err := io.ReadFull(c.reader, header.mask[:]); err != nil {
+			return header, err
+		}
+	}
+
+	// Client must mask every frame, server must not mask any.
+	if header.masked == c.client {
+		return header, &protocolError{code: StatusProtocolError, reason: "invalid frame masking"}
+	}
+
+	if header.opcode >= opClose && (!header.fin || header.length > maxControlPayload) {
+		return header, &protocolError{code: StatusProtocolError, reason: "invalid control frame"}
+	}
+
+	if header.rsv1 && (!c.compress || header.opcode == opContinuation || header.opcode >= opClose) {
+		return header, &protocolError{code: StatusProtocolError, reason: "unexpected compressed frame"}
+	}
+
+	return header, nil
+}
+
+func (c *Conn) readPayload(header frameHeader) ([]byte, error) {
+	var payload = make([]byte, header.length)
+
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return nil, err
+	}
+
+	if header.masked {
+		maskBytes(header.mask, payload)
+	}
+
+	return payload, nil
+}
+
+// writeFrame - sends single frame, nothing can be sent after close frame.
+func (c *Conn) writeFrame(opcode byte, rsv1 bool, payload []byte) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	if c.closeSent {
+		return ErrClosed
+	}
+
+	if opcode == opClose {
+		c.closeSent = true
+		close(c.closing)
+	}
+
+	var (
+		frame  = make([]byte, 0, len(payload)+14)
+		first  = 0x80 | opcode
+		length = len(payload)
+	)
+
+	if rsv1 {
+		first |= 0x40
+	}
+
+	frame = append(frame, first)
+
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+
+	switch {
+	case length <= 125:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	if c.client {
+		var mask [4]byte
+
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+
+		frame = append(frame, mask[:]...)
+		frame = append(frame, payload...)
+
+		maskBytes(mask, frame[len(frame)-length:])
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	_, err := c.conn.Write(frame)
+
+	return err
+}
+
+func maskBytes(mask [4]byte, data []byte) {
+	for i := range data {
+		data[i] ^= mask[i%4]
+	}
+}
+
+// closePayload - returns payload of close frame, reason is cut to fit control frame.
+func closePayload(code StatusCode, reason string) []byte {
+	if code == StatusNoStatus {
+		return nil
+	}
+
+	if len(reason) > maxControlPayload-2 {
+		reason = reason[:maxControlPayload-2]
+
+		for !utf8.ValidString(reason) {
+			reason = reason[:len(reason)-1]
+		}
+	}
+
+	return append(binary.BigEndian.AppendUint16(nil, uint16(code)), reason...)
+}
+
+func parseClose(payload []byte) error {
+	switch {
+	case len(payload) == 0:
+		return &CloseError{Code: StatusNoStatus}
+	case len(payload) == 1:
+		return &protocolError{code: StatusProtocolError, reason: "invalid close frame"}
+	}
+
+	var (
+		code   = StatusCode(binary.BigEndian.Uint16(payload))
+		reason = payload[2:]
+	)
+
+	if !validCloseCode(code) {
+		return &protocolError{code: StatusProtocolError, reason: fmt.Sprintf("invalid close code: %d", code)}
+	}
+
+	if !utf8.Valid(reason) {
+		return &protocolError{code: StatusInvalidPayload, reason: "invalid UTF-8 in close reason"}
+	}
+
+	return &CloseError{Code: code, Reason: string(reason)}
+}
+
+// validCloseCode - reports whether code can be sent in close frame.
+func validCloseCode(code StatusCode) bool {
+	switch {
+	case code >= 3000 && code <= 4999:
+		return true
+	case code < 1000 || code > 1014:
+		return false
+	default:
+		return code != 1004 && code != 1005 && code != 1006
+	}
+}
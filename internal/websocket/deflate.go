@@ -0,0 +1,124 @@
+package websocket
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// deflateExtension - permessage-deflate without context takeover, see RFC 7692.
+// Every message is compressed on its own, so no compression state is kept between messages.
+const deflateExtension = "permessage-deflate; server_no_context_takeover; client_no_context_takeover"
+
+// deflateTail - end of deflate block removed from compressed message and final empty block.
+const deflateTail = "\x00\x00\xff\xff\x01\x00\x00\xff\xff"
+
+var writers = sync.Pool{
+	New: func() any {
+		writer, _ := flate.NewWriter(nil, flate.DefaultCompression)
+
+		return writer
+	},
+}
+
+func compress(data []byte) ([]byte, error) {
+	var (
+		buffer bytes.Buffer
+		writer = writers.Get().(*flate.Writer)
+	)
+
+	defer writers.Put(writer)
+
+	writer.Reset(&buffer)
+
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte(deflateTail[:4])), nil
+}
+
+// decompress - inflates message, fails if it's bigger than limit.
+func decompress(data []byte, limit int64) ([]byte, error) {
+	var reader = flate.NewReader(io.MultiReader(bytes.NewReader(data), strings.NewReader(deflateTail)))
+	defer reader.Close()
+
+	result, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, &protocolError{code: StatusInvalidPayload, reason: "invalid compressed message"}
+	}
+
+	if int64(len(result)) > limit {
+		return nil, &protocolError{code: StatusMessageTooBig, reason: "message too big"}
+	}
+
+	return result, nil
+}
+
+// acceptsDeflate - reports whether client offered permessage-deflate with parameters server can agree to.
+func acceptsDeflate(header http.Header) bool {
+	for _, offer := range headerTokens(header, "Sec-WebSocket-Extensions") {
+		var params = strings.Split(offer, ";")
+
+		if !strings.EqualFold(strings.TrimSpace(params[0]), "permessage-deflate") {
+			continue
+		}
+
+		if acceptableDeflateParams(params[1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func acceptableDeflateParams(params []string) bool {
+	for _, param := range params {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "server_no_context_takeover", "client_no_context_takeover", "client_max_window_bits":
+		case "server_max_window_bits":
+			// Window of compressor can't be limited.
+			if strings.Trim(strings.TrimSpace(value), `"`) != "15" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// headerTokens - returns comma-separated values of header.
+func headerTokens(header http.Header, key string) []string {
+	var tokens []string
+
+	for _, value := range header.Values(key) {
+		for _, token := range strings.Split(value, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+func hasToken(header http.Header, key, token string) bool {
+	for _, value := range headerTokens(header, key) {
+		if strings.EqualFold(value, token) {
+			return true
+		}
+	}
+
+	return false
+}
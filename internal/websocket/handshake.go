@@ -0,0 +1,257 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultReadLimit - default limit of incoming message size.
+	DefaultReadLimit = 1 << 20
+	// DefaultPingInterval - default interval of keepalive pings.
+	DefaultPingInterval = 30 * time.Second
+
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+var (
+	ErrNotWebSocket       = errors.New("not a websocket handshake")
+	ErrUnsupportedVersion = errors.New("unsupported websocket version, supported: 13")
+	ErrBadHandshake       = errors.New("websocket handshake failed")
+)
+
+// Options - settings of connection.
+type Options struct {
+	// ReadLimit - limit of incoming message size in bytes, connection is closed with 1009 code if exceeded.
+	ReadLimit int64
+	// PingInterval - interval of pings, connection is closed if peer is silent for two intervals.
+	// Zero disables pings.
+	PingInterval time.Duration
+	// Compression - permessage-deflate is negotiated if peer supports it.
+	Compression bool
+}
+
+// DefaultOptions - returns options used if none were set.
+func DefaultOptions() Options {
+	return Options{
+		ReadLimit:    DefaultReadLimit,
+		PingInterval: DefaultPingInterval,
+	}
+}
+
+// Setting - changes options of connection, implemented by settings middlewares.
+type Setting interface {
+	Configure(*Options)
+}
+
+// Upgrade - performs server's handshake and takes over connection.
+// Returns 'ErrNotWebSocket' or 'ErrUnsupportedVersion' if request can't be upgraded, nothing is written then.
+func Upgrade(ctx context.Context, w http.ResponseWriter, r *http.Request, options Options) (*Conn, error) {
+	if r.Method != http.MethodGet ||
+		!hasToken(r.Header, "Connection", "upgrade") ||
+		!hasToken(r.Header, "Upgrade", "websocket") {
+		return nil, ErrNotWebSocket
+	}
+
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, ErrUnsupportedVersion
+	}
+
+	var key = r.Header.Get("Sec-WebSocket-Key")
+
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, fmt.Errorf("%w: invalid key", ErrNotWebSocket)
+	}
+
+	var compress = options.Compression && acceptsDeflate(r.Header)
+
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	// Server's timeouts are left on hijacked connection.
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	var header = w.Header().Clone()
+
+	header.Set("Upgrade", "websocket")
+	header.Set("Connection", "Upgrade")
+	header.Set("Sec-WebSocket-Accept", acceptKey(key))
+	header.Del("Content-Type")
+
+	if compress {
+		header.Set("Sec-WebSocket-Extensions", deflateExtension)
+	}
+
+	var response bytes.Buffer
+
+	response.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+	header.Write(&response)
+	response.WriteString("\r\n")
+
+	if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if _, err := conn.Write(response.Bytes()); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	return newConn(ctx, conn, rw.Reader, false, compress, options), nil
+}
+
+// Dial - connects to WebSocket server at 'ws://' or 'wss://' (or 'http://', 'https://') URL.
+// Response of failed handshake is returned with its body for inspection.
+func Dial(ctx context.Context, rawURL string, header http.Header, options Options) (*Conn, *http.Response, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var secure bool
+
+	switch target.Scheme {
+	case "ws", "http":
+		target.Scheme = "http"
+	case "wss", "https":
+		target.Scheme, secure = "https", true
+	default:
+		return nil, nil, fmt.Errorf("unsupported scheme: '%s'", target.Scheme)
+	}
+
+	var address = target.Host
+	if target.Port() == "" {
+		address = net.JoinHostPort(target.Hostname(), map[bool]string{false: "80", true: "443"}[secure])
+	}
+
+	var conn net.Conn
+
+	if secure {
+		conn, err = (&tls.Dialer{Config: &tls.Config{ServerName: target.Hostname()}}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	connection, response, err := clientHandshake(ctx, conn, target, header, options)
+	if err != nil {
+		conn.Close()
+
+		return nil, response, err
+	}
+
+	return connection, response, nil
+}
+
+func clientHandshake(
+	ctx context.Context,
+	conn net.Conn,
+	target *url.URL,
+	header http.Header,
+	options Options,
+) (*Conn, *http.Response, error) {
+	var nonce = make([]byte, 16)
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+
+	var key = base64.StdEncoding.EncodeToString(nonce)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for name, values := range header {
+		request.Header[name] = values
+	}
+
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Sec-WebSocket-Key", key)
+	request.Header.Set("Sec-WebSocket-Version", "13")
+
+	if options.Compression {
+		request.Header.Set("Sec-WebSocket-Extensions", deflateExtension)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := request.Write(conn); err != nil {
+		return nil, nil, err
+	}
+
+	var reader = bufio.NewReader(conn)
+
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(body))
+
+		return nil, response, fmt.Errorf("%w: %s", ErrBadHandshake, response.Status)
+	}
+
+	if !hasToken(response.Header, "Upgrade", "websocket") ||
+		response.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		return nil, response, fmt.Errorf("%w: invalid accept key", ErrBadHandshake)
+	}
+
+	var compress bool
+
+	for _, extension := range headerTokens(response.Header, "Sec-WebSocket-Extensions") {
+		var name, _, _ = strings.Cut(extension, ";")
+
+		if !options.Compression || !strings.EqualFold(strings.TrimSpace(name), "permessage-deflate") {
+			return nil, response, fmt.Errorf("%w: unexpected extension '%s'", ErrBadHandshake, extension)
+		}
+
+		compress = true
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return nil, response, err
+	}
+
+	return newConn(context.Background(), conn, reader, true, compress, options), response, nil
+}
+
+func acceptKey(key string) string {
+	var hash = sha1.Sum([]byte(key + acceptGUID))
+
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,66 @@
+package websocket
+
+import "sync"
+
+// Hub - registry of open connections, closes them on server's shutdown.
+type Hub struct {
+	mutex    sync.Mutex
+	conns    map[*Conn]struct{}
+	shutdown bool
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		conns: make(map[*Conn]struct{}),
+	}
+}
+
+// Add - registers connection, returns false if hub is shut down.
+func (hub *Hub) Add(conn *Conn) bool {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	if hub.shutdown {
+		return false
+	}
+
+	hub.conns[conn] = struct{}{}
+
+	return true
+}
+
+// Remove - unregisters connection.
+func (hub *Hub) Remove(conn *Conn) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	delete(hub.conns, conn)
+}
+
+// Shutdown - closes registered connections with 'StatusGoingAway', new connections are refused.
+func (hub *Hub) Shutdown() {
+	hub.mutex.Lock()
+
+	hub.shutdown = true
+
+	var conns = make([]*Conn, 0, len(hub.conns))
+	for conn := range hub.conns {
+		conns = append(conns, conn)
+	}
+
+	hub.mutex.Unlock()
+
+	var group sync.WaitGroup
+
+	for _, conn := range conns {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			conn.Close(StatusGoingAway, "server is shutting down")
+		}()
+	}
+
+	group.Wait()
+}
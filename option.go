@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/kliuchnikovv/engi/definition/codec"
 	"github.com/kliuchnikovv/engi/definition/response"
+	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/types"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -43,6 +45,48 @@ func WithPrefix(prefix string) Option {
 	}
 }
 
+// WithMiddlewares - sets middlewares applied to every route of every service.
+func WithMiddlewares(middlewares ...Middleware) Option {
+	return func(engine *Engine) {
+		engine.middlewares = append(engine.middlewares, middlewares...)
+	}
+}
+
+// WithTrustedProxies - sets proxies (CIDRs or single IPs) allowed to set forwarding headers
+// ('Forwarded', 'X-Forwarded-For', 'X-Forwarded-Proto', 'X-Forwarded-Host').
+// Resolved values available through 'Request.ClientIP', 'Request.Scheme' and 'Request.Host'.
+//
+// Panics if address can't be parsed.
+func WithTrustedProxies(cidrs ...string) Option {
+	return func(engine *Engine) {
+		for _, cidr := range cidrs {
+			prefix, err := request.ParsePrefix(cidr)
+			if err != nil {
+				panic(fmt.Sprintf("invalid trusted proxy '%s': %s", cidr, err))
+			}
+
+			engine.trustedProxies = append(engine.trustedProxies, prefix)
+		}
+	}
+}
+
+// WithMaxBodySize - limits size of request bodies in bytes for every route,
+// bodies exceeding limit are rejected with 413 code. Can be changed for route by 'parameter.MaxBodySize'.
+func WithMaxBodySize(size int64) Option {
+	return func(engine *Engine) {
+		engine.maxBodySize = size
+	}
+}
+
+// WithCodecs - registers codecs decoding request bodies by their 'Content-Type'
+// and encoding responses by 'Accept' header (marshaler set by 'ResponseAsJSON' or 'ResponseAsXML' is preferred).
+// Codecs replace registered ones with the same media type. JSON, XML and text codecs are registered by default.
+func WithCodecs(codecs ...codec.Codec) Option {
+	return func(engine *Engine) {
+		engine.codecs.Register(codecs...)
+	}
+}
+
 // TODO: remake
 // WithLogger - sets custom logger.
 func WithLogger(handler slog.Handler) Option {
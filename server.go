@@ -11,12 +11,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // RegisterServices registers ServiceAPI implementations into the HTTP mux.
 func (e *Engine) RegisterServices(services ...ServiceDefinition) error {
 	e.services = make([]*Service, len(services))
+	e.docs = docs.New(e.docs.Info.Title, e.docs.Info.Version)
 	mux := http.NewServeMux()
 
 	for i, service := range services {
@@ -100,3 +102,9 @@ func (e *Engine) shutdownEngine() {
 
 	e.logger.Info("engi stopped gracefully")
 }
+
+// Handler - returns handler serving registered services, e.g. for using with 'httptest'.
+// Should be called after 'RegisterServices'.
+func (e *Engine) Handler() http.Handler {
+	return e.server.Handler
+}
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"strings"
 
+	"github.com/kliuchnikovv/engi/definition/codec"
+	"github.com/kliuchnikovv/engi/internal/docs"
 	"github.com/kliuchnikovv/engi/internal/request"
 	"github.com/kliuchnikovv/engi/internal/response"
 	"github.com/kliuchnikovv/engi/internal/routes"
 	"github.com/kliuchnikovv/engi/internal/types"
+	"github.com/kliuchnikovv/engi/internal/websocket"
 )
 
 var (
@@ -39,9 +43,15 @@ type (
 	Service struct {
 		routes routes.Routes
 
-		marshaler types.Marshaler // TODO: remove from here
-		responser types.Responser
+		marshaler   types.Marshaler // TODO: remove from here
+		responser   types.Responser
+		codecs      *codec.Registry
+		middlewares []Middleware
+		proxies     []netip.Prefix
+		maxBodySize int64
+		websockets  *websocket.Hub
 
+		docs   *docs.Document
 		logger *slog.Logger
 
 		api  ServiceDefinition
@@ -62,8 +72,14 @@ func NewService(engine *Engine, api ServiceDefinition, path string) *Service {
 	return &Service{
 		routes: routes.New(),
 
-		marshaler: engine.responseMarshaler,
-		responser: engine.responseObject,
+		marshaler:   engine.responseMarshaler,
+		responser:   engine.responseObject,
+		codecs:      engine.codecs,
+		middlewares: engine.middlewares,
+		proxies:     engine.trustedProxies,
+		maxBodySize: engine.maxBodySize,
+		websockets:  engine.websockets,
+		docs:        engine.docs,
 
 		api:  api,
 		path: path,
@@ -74,12 +90,15 @@ func NewService(engine *Engine, api ServiceDefinition, path string) *Service {
 	}
 }
 
+// Middlewares - returns engine-wide middlewares followed by service ones.
 func (srv *Service) Middlewares() []Middleware {
+	var middlewares = append([]Middleware{}, srv.middlewares...)
+
 	if middlewaresAPI, ok := srv.api.(MiddlewaresAPI); ok {
-		return middlewaresAPI.Middlewares()
+		middlewares = append(middlewares, middlewaresAPI.Middlewares()...)
 	}
 
-	return nil
+	return middlewares
 }
 
 func (srv *Service) addRoute(
@@ -98,7 +117,7 @@ func (srv *Service) addRoute(
 		middlewares = append(middlewares, option)
 	}
 
-	return srv.routes.Add(
+	registered, err := srv.routes.Add(
 		method,
 		path,
 		func(ctx context.Context, request *request.Request, response *response.Response) error {
@@ -106,8 +125,23 @@ func (srv *Service) addRoute(
 		},
 		srv.marshaler,
 		srv.responser,
+		srv.codecs,
 		middlewares...,
 	)
+	if err != nil {
+		return err
+	}
+
+	registered.Operation.Tags = append(registered.Operation.Tags, srv.api.Prefix())
+
+	// Engine-wide limit is described only for routes reading body and not having own limit.
+	if srv.maxBodySize > 0 && registered.Operation.RequestBody != nil && registered.Operation.MaxBodySize == 0 {
+		registered.Operation.LimitBody(srv.maxBodySize)
+	}
+
+	srv.docs.Add(method, srv.path+path, registered.Operation)
+
+	return nil
 }
 
 func (srv *Service) Serve(w http.ResponseWriter, r *http.Request) error {
@@ -118,7 +152,16 @@ func (srv *Service) Serve(w http.ResponseWriter, r *http.Request) error {
 		slog.String("path", r.URL.Path),
 	)
 
-	if err := srv.routes.Handle(context.Background(), r, w, r.Method, uri); err != nil {
+	var req = request.New(r)
+
+	request.SetClient(req, request.ResolveClient(r, srv.proxies))
+	request.SetMaxBodySize(req, srv.maxBodySize)
+	request.SetLogger(req, srv.logger)
+	request.SetCodecs(req, srv.codecs)
+
+	defer request.Close(req)
+
+	if err := srv.routes.Handle(r.Context(), req, w, r.Method, uri); err != nil {
 		return err
 	}
 
@@ -0,0 +1,93 @@
+package engi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/kliuchnikovv/engi/internal/docs"
+	"github.com/kliuchnikovv/engi/internal/request"
+	"github.com/kliuchnikovv/engi/internal/response"
+	"github.com/kliuchnikovv/engi/internal/routes"
+	"github.com/kliuchnikovv/engi/internal/websocket"
+)
+
+type (
+	// WebSocketRoute - handler of WebSocket connection, see 'HandleWebSocket'.
+	WebSocketRoute func(ctx context.Context, request Request, conn *Conn) error
+	// Conn - WebSocket connection.
+	Conn = websocket.Conn
+)
+
+// HandleWebSocket - registers handler of WebSocket connection, e.g. for 'GET' route.
+// Middlewares (e.g. auth or CORS) are run before connection is upgraded, so request can still be rejected.
+// Connection is closed with normal closure when handler returns nil and with internal error code otherwise.
+// 'ctx' is cancelled when connection is closed by peer, on protocol violation or during 'Engine.Shutdown',
+// connections are closed with going away code then.
+// Message size limit, pings and compression are set with settings of 'websocket' package,
+// e.g. 'websocket.ReadLimit', 'websocket.PingInterval', 'websocket.Compression'.
+func HandleWebSocket(route WebSocketRoute, middlewares ...Middleware) RouteByPath {
+	var options = websocket.DefaultOptions()
+
+	for _, middleware := range middlewares {
+		if setting, ok := middleware.(websocket.Setting); ok {
+			setting.Configure(&options)
+		}
+	}
+
+	return func(srv *Service, method, path string) error {
+		return srv.addRoute(
+			method,
+			path,
+			func(ctx context.Context, request *request.Request, response *response.Response) error {
+				conn, err := websocket.Upgrade(ctx, response.ResponseWriter(), request.GetRequest(), options)
+				switch {
+				case errors.Is(err, websocket.ErrUnsupportedVersion):
+					response.ResponseWriter().Header().Set("Sec-WebSocket-Version", "13")
+
+					return response.Errorf(http.StatusUpgradeRequired, "%s", err)
+				case errors.Is(err, websocket.ErrNotWebSocket):
+					return response.BadRequest("%s", err)
+				case err != nil:
+					return err
+				}
+
+				if !srv.websockets.Add(conn) {
+					return conn.Close(websocket.StatusGoingAway, "server is shutting down")
+				}
+
+				defer srv.websockets.Remove(conn)
+
+				if err := route(conn.Context(), request, conn); err != nil {
+					conn.Close(websocket.StatusInternalError, "internal error")
+
+					return err
+				}
+
+				return conn.Close(websocket.StatusNormalClosure, "")
+			},
+			append(middlewares[:len(middlewares):len(middlewares)], webSocket{})...,
+		)
+	}
+}
+
+// webSocket - describes response of WebSocket handshake.
+type webSocket struct{}
+
+func (webSocket) Handle(context.Context, *request.Request, *response.Response) error {
+	return nil
+}
+
+func (webSocket) Docs(route *routes.Route) {
+	route.Operation.Responses[strconv.Itoa(http.StatusSwitchingProtocols)] = &docs.Response{
+		Description: "Switching to WebSocket protocol",
+	}
+	route.Operation.Responses[strconv.Itoa(http.StatusUpgradeRequired)] = &docs.Response{
+		Description: http.StatusText(http.StatusUpgradeRequired),
+	}
+}
+
+func (webSocket) Priority() int {
+	return 0
+}